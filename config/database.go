@@ -35,11 +35,101 @@ func ConnectDatabase() {
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
 	// Auto migrate the schema
-	err = database.AutoMigrate(&models.User{}, &models.Job{}, &models.Application{})
+	err = database.AutoMigrate(
+		&models.User{},
+		&models.Pipeline{},
+		&models.PipelineStage{},
+		&models.Job{},
+		&models.DeletedJob{},
+		&models.Application{},
+		&models.ApplicantProfile{},
+		&models.Bookmark{},
+		&models.JobTerm{},
+		&models.ApplicationStatusEvent{},
+		&models.File{},
+		&models.ParsedResume{},
+		&models.ScreeningQuestion{},
+		&models.ScreeningAnswer{},
+		&models.OrganizationMember{},
+		&models.OrganizationInvitation{},
+		&models.ApplicationNote{},
+		&models.Notification{},
+		&models.ScorecardTemplate{},
+		&models.Scorecard{},
+		&models.Interview{},
+		&models.InterviewSlot{},
+		&models.Message{},
+		&models.MessageThreadRead{},
+		&models.MessageTemplate{},
+		&models.NotificationSettings{},
+		&models.NotificationPreference{},
+		&models.RealtimeEvent{},
+		&models.WebhookEndpoint{},
+		&models.WebhookDelivery{},
+		&models.WebhookDeliveryAttempt{},
+		&models.QueueTask{},
+		&models.Tag{},
+		&models.BulkOperation{},
+		&models.SavedApplicationFilter{},
+		&models.TalentPoolCandidate{},
+		&models.TalentPoolInvitation{},
+		&models.ApplicationReveal{},
+		&models.Offer{},
+		&models.OfferApproval{},
+	)
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	// Full-text index for searching applications by resume contents
+	if err := database.Exec("CREATE INDEX IF NOT EXISTS idx_parsed_resumes_text ON parsed_resumes USING gin (to_tsvector('english', text))").Error; err != nil {
+		log.Fatal("Failed to create resume search index:", err)
+	}
+
+	if err := database.Exec("CREATE INDEX IF NOT EXISTS idx_applications_cover_letter ON applications USING gin (to_tsvector('english', cover_letter))").Error; err != nil {
+		log.Fatal("Failed to create cover letter search index:", err)
+	}
+
+	// Lets stream replays find a user's events
+	if err := database.Exec("CREATE INDEX IF NOT EXISTS idx_realtime_events_user_ids ON realtime_events USING gin (user_ids)").Error; err != nil {
+		log.Fatal("Failed to create realtime event index:", err)
+	}
+
+	// Events from before seq existed keep their ID as their seq
+	if err := database.Exec(`DO $$ BEGIN
+		IF to_regclass('realtime_event_seq') IS NULL THEN
+			CREATE SEQUENCE realtime_event_seq;
+			UPDATE realtime_events SET seq = id;
+			PERFORM setval('realtime_event_seq', COALESCE((SELECT MAX(id) FROM realtime_events), 0) + 1, false);
+		END IF;
+	END $$`).Error; err != nil {
+		log.Fatal("Failed to create realtime event sequence:", err)
+	}
+
+	// Applications from before blind review get a random alias
+	if err := database.Exec("UPDATE applications SET blind_alias = upper(substr(md5(gen_random_uuid()::text), 1, 8)) WHERE blind_alias IS NULL OR blind_alias = ''").Error; err != nil {
+		log.Fatal("Failed to backfill blind review aliases:", err)
+	}
+
+	// Jobs from before organization_id belong to their creator's organization
+	if err := database.Exec(`UPDATE jobs SET organization_id = COALESCE(
+		(SELECT organization_id FROM organization_members WHERE organization_members.user_id = jobs.created_by),
+		created_by) WHERE organization_id IS NULL`).Error; err != nil {
+		log.Fatal("Failed to backfill job organizations:", err)
+	}
+
+	if err := models.MigrateTagNames(database); err != nil {
+		log.Fatal("Failed to migrate tag names:", err)
+	}
+
+	if err := models.MigrateDefaultPipeline(database); err != nil {
+		log.Fatal("Failed to migrate default pipeline:", err)
+	}
+
+	if err := models.BackfillJobTerms(database); err != nil {
+		log.Fatal("Failed to index jobs:", err)
+	}
+
 	DB = database
 	log.Println("Database connected successfully to Neon")
 }
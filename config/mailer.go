@@ -0,0 +1,52 @@
+package config
+
+import (
+	"job-api/mailer"
+	"log"
+	"os"
+)
+
+var Mailer mailer.Mailer
+
+func ConnectMailer() {
+	var err error
+
+	if os.Getenv("UNSUBSCRIBE_SECRET") == "" {
+		log.Fatal("UNSUBSCRIBE_SECRET is required to send email")
+	}
+
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Job API <no-reply@localhost>"
+	}
+
+	switch os.Getenv("MAIL_DRIVER") {
+	case "smtp":
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		Mailer, err = mailer.NewSMTPMailer(
+			os.Getenv("SMTP_HOST"),
+			port,
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			from,
+		)
+	case "memory":
+		Mailer = mailer.NewMemoryMailer()
+	case "", "file":
+		dir := os.Getenv("MAIL_FILE_PATH")
+		if dir == "" {
+			dir = "mail"
+		}
+		Mailer, err = mailer.NewFileMailer(dir, from)
+	default:
+		log.Fatal("Unknown MAIL_DRIVER: ", os.Getenv("MAIL_DRIVER"))
+	}
+
+	if err != nil {
+		log.Fatal("Failed to configure mailer:", err)
+	}
+	log.Println("Mailer configured")
+}
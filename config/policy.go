@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type ReapplyPolicy string
+
+const (
+	ReapplyNever    ReapplyPolicy = "never"
+	ReapplyAlways   ReapplyPolicy = "always"
+	ReapplyCooldown ReapplyPolicy = "cooldown"
+)
+
+// WithdrawalReapplyPolicy reads WITHDRAWAL_REAPPLY_POLICY (default never) and
+// WITHDRAWAL_REAPPLY_COOLDOWN_DAYS (default 30).
+func WithdrawalReapplyPolicy() (ReapplyPolicy, time.Duration) {
+	policy := ReapplyPolicy(os.Getenv("WITHDRAWAL_REAPPLY_POLICY"))
+	switch policy {
+	case ReapplyAlways:
+		return policy, 0
+	case ReapplyCooldown:
+		days, err := strconv.Atoi(os.Getenv("WITHDRAWAL_REAPPLY_COOLDOWN_DAYS"))
+		if err != nil || days < 0 {
+			days = 30
+		}
+		return policy, time.Duration(days) * 24 * time.Hour
+	default:
+		return ReapplyNever, 0
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"log"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Links are never built from requests, which can carry any Host header.
+var PublicBaseURL string
+
+func LoadPublicBaseURL() {
+	PublicBaseURL = strings.TrimRight(os.Getenv("PUBLIC_BASE_URL"), "/")
+	parsed, err := url.Parse(PublicBaseURL)
+	if PublicBaseURL == "" || err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		log.Fatal("PUBLIC_BASE_URL must be set to the http(s) origin the API is served at")
+	}
+}
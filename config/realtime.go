@@ -0,0 +1,32 @@
+package config
+
+import (
+	"context"
+	"job-api/realtime"
+	"log"
+	"os"
+	"time"
+)
+
+// Broker delivers realtime events to the clients connected to this instance.
+var Broker = realtime.NewBroker()
+
+// ConnectRealtime relays events from every instance to this one's clients.
+// LISTEN needs a direct connection, since poolers drop notifications.
+func ConnectRealtime() {
+	directURL := os.Getenv("DATABASE_DIRECT_URL")
+	if directURL == "" {
+		log.Fatal("DATABASE_DIRECT_URL is required for realtime events")
+	}
+	go realtime.Listen(context.Background(), directURL, DB, Broker)
+
+	go func() {
+		for {
+			if err := realtime.Prune(DB); err != nil {
+				log.Println("Failed to prune realtime events:", err)
+			}
+			time.Sleep(time.Hour)
+		}
+	}()
+	log.Println("Realtime events configured")
+}
@@ -0,0 +1,6 @@
+package config
+
+import "job-api/resume"
+
+// Replace ResumeParser to use another parser, such as a hosted service.
+var ResumeParser resume.Parser = resume.NewLocalParser()
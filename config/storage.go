@@ -0,0 +1,41 @@
+package config
+
+import (
+	"job-api/storage"
+	"log"
+	"os"
+)
+
+var Storage storage.Storage
+
+func ConnectStorage() {
+	var err error
+
+	if os.Getenv("FILE_URL_SECRET") == "" {
+		log.Fatal("FILE_URL_SECRET is required to sign download URLs")
+	}
+
+	switch os.Getenv("STORAGE_DRIVER") {
+	case "s3":
+		Storage, err = storage.NewS3Storage(
+			os.Getenv("S3_ENDPOINT"),
+			os.Getenv("S3_REGION"),
+			os.Getenv("S3_BUCKET"),
+			os.Getenv("S3_ACCESS_KEY_ID"),
+			os.Getenv("S3_SECRET_ACCESS_KEY"),
+		)
+	case "", "local":
+		root := os.Getenv("STORAGE_LOCAL_PATH")
+		if root == "" {
+			root = "uploads"
+		}
+		Storage, err = storage.NewLocalStorage(root)
+	default:
+		log.Fatal("Unknown STORAGE_DRIVER: ", os.Getenv("STORAGE_DRIVER"))
+	}
+
+	if err != nil {
+		log.Fatal("Failed to configure file storage:", err)
+	}
+	log.Println("File storage configured")
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Saved filters may only hold these parameters.
+var applicationFilterKeys = map[string]bool{
+	"q": true, "skill": true, "min_experience": true, "status": true, "tag": true,
+	"min_rating": true, "max_rating": true, "applied_from": true, "applied_to": true,
+	"assignee_id": true, "sort": true,
+}
+
+// applicationFilters narrows a job's applications. Repeated statuses match
+// any of them; repeated tags and skills must all be present.
+type applicationFilters struct {
+	Query         string
+	Skills        []string
+	MinExperience *float64
+	Statuses      []string
+	Tags          []string
+	MinRating     *float64
+	MaxRating     *float64
+	AppliedFrom   *time.Time
+	AppliedTo     *time.Time
+	AssigneeID    *uuid.UUID
+	Answers       map[uuid.UUID][]string
+	Sort          string
+}
+
+// The saved filter named by view fills in parameters the request does not set.
+func applicationFilterParams(c *gin.Context, userID uuid.UUID) (url.Values, error) {
+	params := c.Request.URL.Query()
+	viewID := params.Get("view")
+	if viewID == "" {
+		return params, nil
+	}
+
+	viewUUID, err := uuid.Parse(viewID)
+	if err != nil {
+		return nil, errors.New("invalid view ID")
+	}
+	view, err := findSavedFilter(viewUUID, userID)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := url.ParseQuery(view.Query)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range saved {
+		if _, ok := params[key]; !ok {
+			params[key] = values
+		}
+	}
+	return params, nil
+}
+
+func parseApplicationFilters(params url.Values) (applicationFilters, []string) {
+	filters := applicationFilters{
+		Query:    strings.TrimSpace(params.Get("q")),
+		Skills:   utils.NormalizeSkills(params["skill"]),
+		Statuses: nonEmpty(params["status"]),
+		Tags:     nonEmpty(params["tag"]),
+		Answers:  make(map[uuid.UUID][]string),
+		Sort:     params.Get("sort"),
+	}
+
+	var errs []string
+	parseFloat := func(key string) *float64 {
+		value := params.Get(key)
+		if value == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, key+" must be a number")
+			return nil
+		}
+		return &f
+	}
+	filters.MinExperience = parseFloat("min_experience")
+	filters.MinRating = parseFloat("min_rating")
+	filters.MaxRating = parseFloat("max_rating")
+
+	parseDate := func(key string, endOfDay bool) *time.Time {
+		value := params.Get(key)
+		if value == "" {
+			return nil
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return &t
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			errs = append(errs, key+" must be a date (2006-01-02) or RFC 3339 time")
+			return nil
+		}
+		if endOfDay {
+			// A date-only upper bound includes that whole day
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		return &t
+	}
+	filters.AppliedFrom = parseDate("applied_from", false)
+	filters.AppliedTo = parseDate("applied_to", true)
+
+	if value := params.Get("assignee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			errs = append(errs, "assignee_id must be a user ID")
+		} else {
+			filters.AssigneeID = &id
+		}
+	}
+
+	// Screening answers are filtered as answer[<question_id>]=<value>
+	for key, values := range params {
+		if !strings.HasPrefix(key, "answer[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		questionID, err := uuid.Parse(strings.TrimSuffix(strings.TrimPrefix(key, "answer["), "]"))
+		if err != nil {
+			errs = append(errs, key+" must name a screening question ID")
+			continue
+		}
+		filters.Answers[questionID] = append(filters.Answers[questionID], nonEmpty(values)...)
+	}
+
+	switch filters.Sort {
+	case "", "rating_desc", "rating_asc", "applied_at_desc", "applied_at":
+	default:
+		errs = append(errs, "sort must be rating_desc, rating_asc, applied_at_desc or applied_at")
+	}
+	return filters, errs
+}
+
+func (f applicationFilters) scope(orgID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if f.Query != "" {
+			// Hidden applicants never match, as a match on their name or
+			// email would single them out
+			resumes := config.DB.Model(&models.ParsedResume{}).Select("application_id").
+				Where("application_id IS NOT NULL AND status = ?", models.ResumeParsed).
+				Where("to_tsvector('english', text) @@ plainto_tsquery('english', ?)", f.Query)
+			db = db.Where(applicationVisible).
+				Where("(to_tsvector('english', cover_letter) @@ plainto_tsquery('english', ?) OR id IN (?))", f.Query, resumes)
+		}
+		if len(f.Skills) > 0 || f.MinExperience != nil {
+			resumes := config.DB.Model(&models.ParsedResume{}).Select("application_id").
+				Where("application_id IS NOT NULL AND status = ?", models.ResumeParsed)
+			if len(f.Skills) > 0 {
+				encoded, _ := json.Marshal(f.Skills)
+				resumes = resumes.Where("skills @> ?::jsonb", string(encoded))
+			}
+			if f.MinExperience != nil {
+				resumes = resumes.Where("years_experience >= ?", *f.MinExperience)
+			}
+			db = db.Where("id IN (?)", resumes)
+		}
+		if len(f.Statuses) > 0 {
+			var lowered []string
+			for _, status := range f.Statuses {
+				lowered = append(lowered, strings.ToLower(status))
+			}
+			db = db.Where("LOWER(status) IN ?", lowered)
+		}
+		for _, tag := range f.Tags {
+			db = db.Where("id IN (?)", config.DB.Table("application_tags").
+				Select("application_tags.application_id").
+				Joins("JOIN tags ON tags.id = application_tags.tag_id").
+				Where("tags.organization_id = ? AND LOWER(tags.name) = ?", orgID, strings.ToLower(tag)))
+		}
+		if f.MinRating != nil {
+			db = db.Where("average_rating >= ?", *f.MinRating)
+		}
+		if f.MaxRating != nil {
+			db = db.Where("average_rating <= ?", *f.MaxRating)
+		}
+		if f.AppliedFrom != nil {
+			db = db.Where("applied_at >= ?", *f.AppliedFrom)
+		}
+		if f.AppliedTo != nil {
+			db = db.Where("applied_at <= ?", *f.AppliedTo)
+		}
+		if f.AssigneeID != nil {
+			db = db.Where("assignee_id = ?", *f.AssigneeID)
+		}
+		for questionID, values := range f.Answers {
+			for _, value := range values {
+				db = db.Where("id IN (?)", answerMatches(questionID, value))
+			}
+		}
+		return db
+	}
+}
+
+func answerMatches(questionID uuid.UUID, value string) *gorm.DB {
+	candidates := []string{}
+	if encoded, err := json.Marshal(value); err == nil {
+		candidates = append(candidates, string(encoded))
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		candidates = append(candidates, value)
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		candidates = append(candidates, strconv.FormatBool(b))
+	}
+
+	answers := config.DB.Model(&models.ScreeningAnswer{}).Select("application_id").Where("question_id = ?", questionID)
+	match := config.DB
+	for i, candidate := range candidates {
+		clause := "value = ?::jsonb OR value @> ?::jsonb"
+		if i == 0 {
+			match = match.Where(clause, candidate, "["+candidate+"]")
+		} else {
+			match = match.Or(clause, candidate, "["+candidate+"]")
+		}
+	}
+	return answers.Where(match)
+}
+
+// usesRatings reports whether the filters filter or sort by rating.
+func (f applicationFilters) usesRatings() bool {
+	return f.MinRating != nil || f.MaxRating != nil || f.Sort == "rating_desc" || f.Sort == "rating_asc"
+}
+
+// order maps the sort parameter to an ORDER BY clause.
+func (f applicationFilters) order() string {
+	switch f.Sort {
+	case "rating_desc":
+		return "average_rating DESC NULLS LAST, applied_at ASC"
+	case "rating_asc":
+		return "average_rating ASC NULLS LAST, applied_at ASC"
+	case "applied_at_desc":
+		return "applied_at DESC"
+	default:
+		return "applied_at ASC"
+	}
+}
+
+func nonEmpty(values []string) []string {
+	var kept []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
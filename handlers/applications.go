@@ -1,25 +1,41 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"job-api/config"
 	"job-api/models"
 	"job-api/utils"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ApplyJobRequest struct {
-	ResumeLink  string `json:"resume_link" validate:"required,url"`
-	CoverLetter string `json:"cover_letter" validate:"max=200"`
+	ResumeLink    string                   `json:"resume_link" validate:"required_without=ResumeFileID,omitempty,url"`
+	ResumeFileID  *uuid.UUID               `json:"resume_file_id"`
+	AttachmentIDs []uuid.UUID              `json:"attachment_ids" validate:"max=5"`
+	CoverLetter   string                   `json:"cover_letter" validate:"max=200"`
+	Answers       []ScreeningAnswerRequest `json:"answers" validate:"max=50,dive"`
 }
 
 type UpdateApplicationStatusRequest struct {
-	Status models.ApplicationStatus `json:"status" validate:"required,oneof=Applied Reviewed Interview Rejected Hired"`
+	StageID *uuid.UUID               `json:"stage_id"`
+	Status  models.ApplicationStatus `json:"status" validate:"required_without=StageID,max=100"`
+	Reason  string                   `json:"reason" validate:"max=500"`
 }
 
+var (
+	errApplicationNotFound = errors.New("application not found")
+	errNotHiringTeam       = errors.New("not on the hiring team for this job")
+	errStatusConflict      = errors.New("application status was changed by another request")
+)
+
 func ApplyForJob(c *gin.Context) {
 	jobID := c.Param("id")
 	jobUUID, err := uuid.Parse(jobID)
@@ -67,28 +83,236 @@ func ApplyForJob(c *gin.Context) {
 		return
 	}
 
+	if job.Status != models.JobStatusOpen {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Job is no longer accepting applications",
+			Object:  nil,
+		})
+		return
+	}
+
 	// Check if user already applied
 	var existingApplication models.Application
 	if err := config.DB.Where("applicant_id = ? AND job_id = ?", applicantID, jobUUID).
+		Order("applied_at DESC").
 		First(&existingApplication).Error; err == nil {
-		c.JSON(http.StatusConflict, models.BaseResponse{
+		if existingApplication.Status != models.StatusWithdrawn {
+			c.JSON(http.StatusConflict, models.BaseResponse{
+				Success: false,
+				Message: "You have already applied to this job",
+				Object:  nil,
+				Errors:  []string{"Duplicate application"},
+			})
+			return
+		}
+
+		if err := checkReapplyAllowed(existingApplication); err != nil {
+			c.JSON(http.StatusConflict, models.BaseResponse{
+				Success: false,
+				Message: "You cannot apply to this job again",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	fileIDs := append([]uuid.UUID(nil), req.AttachmentIDs...)
+	if req.ResumeFileID != nil {
+		fileIDs = append(fileIDs, *req.ResumeFileID)
+	}
+	files, err := loadApplicantFiles(applicantID, fileIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid files",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	for _, file := range files {
+		if req.ResumeFileID != nil && file.ID == *req.ResumeFileID && file.Kind != models.FileKindResume {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Invalid files",
+				Object:  nil,
+				Errors:  []string{"resume_file_id must refer to an uploaded resume"},
+			})
+			return
+		}
+	}
+
+	questions, err := loadScreeningQuestions(job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to load screening questions",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	answers, knockouts, err := evaluateScreeningAnswers(questions, req.Answers)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid screening answers",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// New applications enter the first stage of the job's pipeline
+	var firstStage models.PipelineStage
+	pipeline, err := loadJobPipeline(job)
+	if err == nil {
+		firstStage, err = pipeline.FirstStage()
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
 			Success: false,
-			Message: "You have already applied to this job",
+			Message: "Failed to load job pipeline",
 			Object:  nil,
-			Errors:  []string{"Duplicate application"},
+			Errors:  []string{err.Error()},
 		})
 		return
 	}
 
+	// Candidates failing a knockout question go straight to a rejected stage
+	var rejectStage *models.PipelineStage
+	if len(knockouts) > 0 {
+		if stage, ok := pipeline.RejectedStage(); ok {
+			rejectStage = &stage
+		}
+	}
+
 	application := models.Application{
-		ApplicantID: applicantID,
-		JobID:       jobUUID,
-		ResumeLink:  req.ResumeLink,
-		CoverLetter: req.CoverLetter,
-		Status:      models.StatusApplied,
+		ApplicantID:  applicantID,
+		JobID:        jobUUID,
+		ResumeLink:   req.ResumeLink,
+		ResumeFileID: req.ResumeFileID,
+		CoverLetter:  req.CoverLetter,
+		Status:       models.ApplicationStatus(firstStage.Name),
+		StageID:      &firstStage.ID,
 	}
 
-	if err := config.DB.Create(&application).Error; err != nil {
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockStage(tx, firstStage.ID); err != nil {
+			return err
+		}
+		if err := tx.Create(&application).Error; err != nil {
+			return err
+		}
+		if len(fileIDs) > 0 {
+			// Only claim files that are still unattached
+			result := tx.Model(&models.File{}).Where("id IN ? AND application_id IS NULL", fileIDs).
+				Update("application_id", application.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != int64(len(uniqueIDs(fileIDs))) {
+				return errors.New("files were used by another request")
+			}
+		}
+		if req.ResumeFileID != nil {
+			if err := tx.Model(&models.ParsedResume{}).Where("file_id = ?", *req.ResumeFileID).
+				Update("application_id", application.ID).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(&models.ApplicationStatusEvent{
+			ApplicationID: application.ID,
+			ToStatus:      application.Status,
+			ToStageID:     application.StageID,
+			ActorID:       &applicantID,
+		}).Error; err != nil {
+			return err
+		}
+
+		// Link the application to any talent pool invitation for this job
+		if err := tx.Model(&models.TalentPoolInvitation{}).
+			Where("job_id = ? AND applicant_id = ? AND application_id IS NULL", jobUUID, applicantID).
+			Update("application_id", application.ID).Error; err != nil {
+			return err
+		}
+
+		for i := range answers {
+			answers[i].ApplicationID = application.ID
+		}
+		if len(answers) > 0 {
+			if err := tx.Create(&answers).Error; err != nil {
+				return err
+			}
+		}
+
+		var applicant models.User
+		if err := tx.First(&applicant, applicantID).Error; err != nil {
+			return err
+		}
+		applicantName := applicant.Name
+		if job.BlindReview {
+			applicantName = blindAlias(application)
+		}
+		if err := createNotifications(tx, []models.Notification{{
+			UserID: job.CreatedBy,
+			Type:   models.NotificationNewApplication,
+			Title:  "New application for " + job.Title,
+			Body:   applicantName + " applied for " + job.Title,
+			Data: map[string]string{
+				"application_id": application.ID.String(),
+				"job_id":         job.ID.String(),
+			},
+		}}); err != nil {
+			return err
+		}
+		if err := publishApplicationEvent(tx, EventApplicationCreated, application, job, map[string]interface{}{
+			"status": application.Status,
+		}); err != nil {
+			return err
+		}
+
+		if rejectStage != nil {
+			event := models.ApplicationStatusEvent{
+				ApplicationID: application.ID,
+				FromStatus:    application.Status,
+				ToStatus:      models.ApplicationStatus(rejectStage.Name),
+				FromStageID:   application.StageID,
+				ToStageID:     &rejectStage.ID,
+				Reason:        "Automatically rejected by screening questions: " + strings.Join(knockouts, "; "),
+			}
+			if err := lockStage(tx, rejectStage.ID); err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Application{}).Where("id = ?", application.ID).
+				Updates(map[string]interface{}{"status": rejectStage.Name, "stage_id": rejectStage.ID}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&event).Error; err != nil {
+				return err
+			}
+			if err := publishApplicationEvent(tx, EventApplicationStatusChanged, application, job, map[string]interface{}{
+				"from_status": event.FromStatus,
+				"to_status":   event.ToStatus,
+			}); err != nil {
+				return err
+			}
+			application.Status = event.ToStatus
+			application.StageID = event.ToStageID
+		}
+
+		application.Applicant = applicant
+		emailData := applicationEmailData(application, job)
+		teamEmailData := applicationEmailData(application, job)
+		teamEmailData["applicant_name"] = applicantName
+		return queueNotificationEmails(tx,
+			notificationEmail{UserID: applicantID, Type: models.NotificationApplicationSubmitted, Data: emailData},
+			notificationEmail{UserID: job.CreatedBy, Type: models.NotificationNewApplication, Data: teamEmailData},
+		)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.BaseResponse{
 			Success: false,
 			Message: "Failed to submit application",
@@ -208,7 +432,7 @@ func GetJobApplications(c *gin.Context) {
 		return
 	}
 
-	if job.CreatedBy != currentUserID {
+	if !canManageJob(currentUserID, job) {
 		c.JSON(http.StatusForbidden, models.BaseResponse{
 			Success: false,
 			Message: "Unauthorized access",
@@ -217,12 +441,53 @@ func GetJobApplications(c *gin.Context) {
 		return
 	}
 
+	params, err := applicationFilterParams(c, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid saved filter",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	filters, errs := parseApplicationFilters(params)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid filters",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+	orgID := job.OrganizationID
+
+	// Only the job's owning account may filter or sort by ratings
+	ratingsVisible := job.CreatedBy == currentUserID
+	if !ratingsVisible && filters.usesRatings() {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Rating filters and sorting are only available to the job's owner",
+			Object:  nil,
+		})
+		return
+	}
+
 	var total int64
-	config.DB.Model(&models.Application{}).Where("job_id = ?", jobUUID).Count(&total)
+	config.DB.Model(&models.Application{}).Where("job_id = ?", jobUUID).
+		Scopes(filters.scope(orgID)).Count(&total)
 
 	var applications []models.Application
 	if err := config.DB.Where("job_id = ?", jobUUID).
+		Scopes(filters.scope(orgID)).
 		Preload("Applicant").
+		Preload("ParsedResume", func(db *gorm.DB) *gorm.DB {
+			return db.Omit("text")
+		}).
+		Preload("Answers").
+		Preload("Tags").
+		Order(filters.order()).
 		Offset(offset).Limit(pageSize).Find(&applications).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.BaseResponse{
 			Success: false,
@@ -235,24 +500,76 @@ func GetJobApplications(c *gin.Context) {
 
 	// Transform response to include required fields
 	type ApplicationResponse struct {
-		ID            uuid.UUID `json:"id"`
-		ApplicantName string    `json:"applicant_name"`
-		ResumeLink    string    `json:"resume_link"`
-		CoverLetter   string    `json:"cover_letter"`
-		Status        string    `json:"status"`
-		AppliedAt     string    `json:"applied_at"`
+		ID               uuid.UUID                `json:"id"`
+		ApplicantName    string                   `json:"applicant_name"`
+		ResumeLink       string                   `json:"resume_link"`
+		ResumeFileID     *uuid.UUID               `json:"resume_file_id,omitempty"`
+		AttachmentIDs    []uuid.UUID              `json:"attachment_ids,omitempty"`
+		CoverLetter      string                   `json:"cover_letter"`
+		Status           string                   `json:"status"`
+		AppliedAt        string                   `json:"applied_at"`
+		WithdrawnAt      string                   `json:"withdrawn_at,omitempty"`
+		WithdrawalReason string                   `json:"withdrawal_reason,omitempty"`
+		ParsedResume     *models.ParsedResume     `json:"parsed_resume,omitempty"`
+		Answers          []models.ScreeningAnswer `json:"screening_answers,omitempty"`
+		AverageRating    *float64                 `json:"average_rating"`
+		ScorecardCount   *int                     `json:"scorecard_count"`
+		AssigneeID       *uuid.UUID               `json:"assignee_id"`
+		Tags             []models.Tag             `json:"tags"`
+		Redacted         bool                     `json:"redacted,omitempty"`
+	}
+
+	applicationIDs := make([]uuid.UUID, len(applications))
+	for i, app := range applications {
+		applicationIDs[i] = app.ID
+	}
+	var attachments []models.File
+	if len(applicationIDs) > 0 {
+		config.DB.Select("id", "application_id").
+			Where("application_id IN ? AND kind = ? AND message_id IS NULL AND offer_id IS NULL", applicationIDs, models.FileKindAttachment).
+			Find(&attachments)
 	}
+	attachmentIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, file := range attachments {
+		attachmentIDs[*file.ApplicationID] = append(attachmentIDs[*file.ApplicationID], file.ID)
+	}
+
+	// Hidden applicants are listed under an alias
+	review := loadBlindReview(job)
+	scored := scoredApplications(currentUserID, applicationIDs)
 
 	var response []ApplicationResponse
 	for _, app := range applications {
-		response = append(response, ApplicationResponse{
-			ID:            app.ID,
-			ApplicantName: app.Applicant.Name,
-			ResumeLink:    app.ResumeLink,
-			CoverLetter:   app.CoverLetter,
-			Status:        string(app.Status),
-			AppliedAt:     app.AppliedAt.Format("2006-01-02 15:04:05"),
-		})
+		if review.hidden(app) {
+			redactApplication(&app)
+			delete(attachmentIDs, app.ID)
+		}
+		item := ApplicationResponse{
+			ID:               app.ID,
+			ApplicantName:    app.Applicant.Name,
+			ResumeLink:       app.ResumeLink,
+			ResumeFileID:     app.ResumeFileID,
+			AttachmentIDs:    attachmentIDs[app.ID],
+			CoverLetter:      app.CoverLetter,
+			Status:           string(app.Status),
+			AppliedAt:        app.AppliedAt.Format("2006-01-02 15:04:05"),
+			WithdrawalReason: app.WithdrawalReason,
+			ParsedResume:     app.ParsedResume,
+			Answers:          app.Answers,
+			AssigneeID:       app.AssigneeID,
+			Tags:             app.Tags,
+			Redacted:         app.Redacted,
+		}
+		// Members see ratings once they have submitted their own scorecard
+		if ratingsVisible || scored[app.ID] {
+			scorecardCount := app.ScorecardCount
+			item.AverageRating = app.AverageRating
+			item.ScorecardCount = &scorecardCount
+		}
+		if app.WithdrawnAt != nil {
+			item.WithdrawnAt = app.WithdrawnAt.Format("2006-01-02 15:04:05")
+		}
+		response = append(response, item)
 	}
 
 	c.JSON(http.StatusOK, models.PaginatedResponse{
@@ -302,7 +619,7 @@ func UpdateApplicationStatus(c *gin.Context) {
 	currentUserID := userID.(uuid.UUID)
 
 	var application models.Application
-	if err := config.DB.Preload("Job").First(&application, appUUID).Error; err != nil {
+	if err := config.DB.Preload("Applicant").Preload("Job").First(&application, appUUID).Error; err != nil {
 		c.JSON(http.StatusNotFound, models.BaseResponse{
 			Success: false,
 			Message: "Application not found",
@@ -312,7 +629,7 @@ func UpdateApplicationStatus(c *gin.Context) {
 	}
 
 	// Check if current user owns the job
-	if application.Job.CreatedBy != currentUserID {
+	if !canManageJob(currentUserID, application.Job) {
 		c.JSON(http.StatusForbidden, models.BaseResponse{
 			Success: false,
 			Message: "Unauthorized",
@@ -321,8 +638,84 @@ func UpdateApplicationStatus(c *gin.Context) {
 		return
 	}
 
-	application.Status = req.Status
-	if err := config.DB.Save(&application).Error; err != nil {
+	if application.Status == models.StatusWithdrawn {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Invalid status transition",
+			Object:  nil,
+			Errors:  []string{"application has been withdrawn by the applicant"},
+		})
+		return
+	}
+
+	pipeline, err := loadJobPipeline(application.Job)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to load job pipeline",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	target, ok := pipeline.FindStage(req.StageID, string(req.Status))
+	if !ok {
+		var names []string
+		for _, stage := range pipeline.OrderedStages() {
+			names = append(names, stage.Name)
+		}
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Unknown stage",
+			Object:  nil,
+			Errors:  []string{"stage must be one of: " + strings.Join(names, ", ")},
+		})
+		return
+	}
+
+	current, ok := pipeline.FindStage(application.StageID, string(application.Status))
+	if !ok {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Application is not in a stage of the job pipeline",
+			Object:  nil,
+		})
+		return
+	}
+
+	if err := pipeline.ValidateTransition(current, target); err != nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Invalid status transition",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	event := models.ApplicationStatusEvent{
+		ApplicationID: application.ID,
+		FromStatus:    application.Status,
+		ToStatus:      models.ApplicationStatus(target.Name),
+		FromStageID:   &current.ID,
+		ToStageID:     &target.ID,
+		ActorID:       &currentUserID,
+		Reason:        req.Reason,
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		return changeApplicationStatus(tx, application, event)
+	})
+	if errors.Is(err, errStatusConflict) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Application was updated by another request, please retry",
+			Object:  nil,
+		})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.BaseResponse{
 			Success: false,
 			Message: "Failed to update application status",
@@ -333,7 +726,13 @@ func UpdateApplicationStatus(c *gin.Context) {
 	}
 
 	// Load relationships for response
-	config.DB.Preload("Applicant").Preload("Job").First(&application, application.ID)
+	config.DB.Preload("Applicant").Preload("Job").Preload("Stage").First(&application, application.ID)
+	if applicationHidden(application) {
+		redactApplication(&application)
+	}
+	if !canSeeRatings(currentUserID, application) {
+		hideRatings(&application)
+	}
 
 	c.JSON(http.StatusOK, models.BaseResponse{
 		Success: true,
@@ -341,3 +740,307 @@ func UpdateApplicationStatus(c *gin.Context) {
 		Object:  application,
 	})
 }
+
+// changeApplicationStatus needs the application's Job and Applicant loaded.
+func changeApplicationStatus(tx *gorm.DB, application models.Application, event models.ApplicationStatusEvent) error {
+	if event.ToStageID != nil {
+		if err := lockStage(tx, *event.ToStageID); err != nil {
+			return err
+		}
+	}
+	// Guard against a concurrent update having moved the application already
+	result := tx.Model(&models.Application{}).
+		Where("id = ? AND status = ?", application.ID, application.Status).
+		Updates(map[string]interface{}{"status": event.ToStatus, "stage_id": event.ToStageID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errStatusConflict
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+	if err := revealOnStageChange(tx, application, event); err != nil {
+		return err
+	}
+	if err := createNotifications(tx, []models.Notification{{
+		UserID: application.ApplicantID,
+		Type:   models.NotificationStatusChanged,
+		Title:  "Update on your application for " + application.Job.Title,
+		Body:   "Your application moved from " + string(event.FromStatus) + " to " + string(event.ToStatus),
+		Data: map[string]string{
+			"application_id": application.ID.String(),
+			"job_id":         application.JobID.String(),
+		},
+	}}); err != nil {
+		return err
+	}
+	if err := publishApplicationEvent(tx, EventApplicationStatusChanged, application, application.Job, map[string]interface{}{
+		"from_status": event.FromStatus,
+		"to_status":   event.ToStatus,
+	}); err != nil {
+		return err
+	}
+
+	emailData := applicationEmailData(application, application.Job)
+	emailData["status"] = string(event.ToStatus)
+	emailData["from_status"] = string(event.FromStatus)
+	return queueNotificationEmails(tx,
+		notificationEmail{UserID: application.ApplicantID, Type: models.NotificationStatusChanged, Data: emailData},
+	)
+}
+
+func GetApplicationHistory(c *gin.Context) {
+	applicationID := c.Param("id")
+	appUUID, err := uuid.Parse(applicationID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	userRole, _ := c.Get("user_role")
+
+	var application models.Application
+	if err := config.DB.Preload("Job").First(&application, appUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Application not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	isApplicant := userRole == string(models.RoleApplicant) && application.ApplicantID == currentUserID
+	isCompany := userRole == string(models.RoleCompany) && canManageJob(currentUserID, application.Job)
+	if !isApplicant && !isCompany {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Object:  nil,
+		})
+		return
+	}
+
+	var events []models.ApplicationStatusEvent
+	if err := config.DB.Where("application_id = ?", application.ID).
+		Order("created_at ASC").Find(&events).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch application history",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Reasons are internal to the hiring company
+	if isApplicant {
+		for i := range events {
+			events[i].Reason = ""
+		}
+	} else if applicationHidden(application) {
+		for i := range events {
+			if events[i].ActorID != nil && *events[i].ActorID == application.ApplicantID {
+				events[i].ActorID = nil
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Application history retrieved successfully",
+		Object:  events,
+	})
+}
+
+type WithdrawApplicationRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+func WithdrawApplication(c *gin.Context) {
+	applicationID := c.Param("id")
+	appUUID, err := uuid.Parse(applicationID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	// The body is optional
+	var req WithdrawApplicationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Invalid request data",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	applicantID := userID.(uuid.UUID)
+
+	var application models.Application
+	if err := config.DB.Preload("Job").First(&application, appUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Application not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if application.ApplicantID != applicantID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Object:  nil,
+		})
+		return
+	}
+
+	if application.Status == models.StatusWithdrawn {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Application has already been withdrawn",
+			Object:  nil,
+		})
+		return
+	}
+
+	// Applications that reached a final stage can no longer be withdrawn
+	if pipeline, err := loadJobPipeline(application.Job); err == nil {
+		if stage, ok := pipeline.FindStage(application.StageID, string(application.Status)); ok && stage.IsTerminal() {
+			c.JSON(http.StatusConflict, models.BaseResponse{
+				Success: false,
+				Message: "Application can no longer be withdrawn",
+				Object:  nil,
+				Errors:  []string{fmt.Sprintf("application is already in final stage %s", stage.Name)},
+			})
+			return
+		}
+	}
+
+	now := time.Now()
+	event := models.ApplicationStatusEvent{
+		ApplicationID: application.ID,
+		FromStatus:    application.Status,
+		ToStatus:      models.StatusWithdrawn,
+		FromStageID:   application.StageID,
+		ActorID:       &applicantID,
+		Reason:        req.Reason,
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Application{}).
+			Where("id = ? AND status = ?", application.ID, application.Status).
+			Updates(map[string]interface{}{
+				"status":            models.StatusWithdrawn,
+				"withdrawn_at":      now,
+				"withdrawal_reason": req.Reason,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errStatusConflict
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		// Withdrawals are news for the hiring team rather than the applicant
+		if err := createNotifications(tx, []models.Notification{{
+			UserID: application.Job.CreatedBy,
+			Type:   models.NotificationApplicationWithdrawn,
+			Title:  "Application withdrawn for " + application.Job.Title,
+			Body:   "An applicant withdrew their application from the " + string(event.FromStatus) + " stage",
+			Data: map[string]string{
+				"application_id": application.ID.String(),
+				"job_id":         application.JobID.String(),
+			},
+		}}); err != nil {
+			return err
+		}
+		return publishApplicationEvent(tx, EventApplicationStatusChanged, application, application.Job, map[string]interface{}{
+			"from_status": event.FromStatus,
+			"to_status":   event.ToStatus,
+		})
+	})
+	if errors.Is(err, errStatusConflict) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Application was updated by another request, please retry",
+			Object:  nil,
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to withdraw application",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	config.DB.Preload("Job").First(&application, application.ID)
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Application withdrawn successfully",
+		Object:  application,
+	})
+}
+
+func checkReapplyAllowed(withdrawn models.Application) error {
+	policy, cooldown := config.WithdrawalReapplyPolicy()
+	switch policy {
+	case config.ReapplyAlways:
+		return nil
+	case config.ReapplyCooldown:
+		if withdrawn.WithdrawnAt == nil {
+			return nil
+		}
+		if allowedAt := withdrawn.WithdrawnAt.Add(cooldown); time.Now().Before(allowedAt) {
+			return fmt.Errorf("you can apply again after %s", allowedAt.Format("2006-01-02 15:04:05"))
+		}
+		return nil
+	default:
+		return errors.New("re-applying after withdrawing is not allowed")
+	}
+}
+
+// Jobs without a pipeline use the default one.
+func loadJobPipeline(job models.Job) (models.Pipeline, error) {
+	if job.PipelineID == nil {
+		return models.DefaultPipeline(config.DB)
+	}
+
+	var pipeline models.Pipeline
+	err := config.DB.Preload("Stages").First(&pipeline, *job.PipelineID).Error
+	return pipeline, err
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"job-api/config"
+	"job-api/models"
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestUpdateApplicationStatusConflict(t *testing.T) {
+	setupTestDB(t)
+	company := createTestUser(t, "Acme", models.RoleCompany)
+	applicant := createTestUser(t, "Jane", models.RoleApplicant)
+	job := createTestJob(t, company, models.Job{})
+	application := createTestApplication(t, job, applicant, "")
+
+	// Another request rejects the application after the handler loaded it,
+	// just before the handler writes the new status
+	raced := false
+	callbacks := config.DB.Callback().Update()
+	if err := callbacks.Before("gorm:update").Register("test:race", func(db *gorm.DB) {
+		if raced || db.Statement.Table != "applications" {
+			return
+		}
+		raced = true
+		db.Session(&gorm.Session{NewDB: true}).
+			Exec("UPDATE applications SET status = ? WHERE id = ?", models.StatusRejected, application.ID)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { callbacks.Remove("test:race") })
+
+	recorder := serveTest(t, UpdateApplicationStatus, http.MethodPut, "/applications/:id/status",
+		"/applications/"+application.ID.String()+"/status", company, UpdateApplicationStatusRequest{Status: models.StatusReviewed})
+	expectStatus(t, recorder, http.StatusConflict)
+
+	var stored models.Application
+	if err := config.DB.First(&stored, application.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != models.StatusRejected {
+		t.Errorf("status = %s, want the concurrent %s to stand", stored.Status, models.StatusRejected)
+	}
+	var events int64
+	config.DB.Model(&models.ApplicationStatusEvent{}).Where("application_id = ?", application.ID).Count(&events)
+	if events != 0 {
+		t.Errorf("recorded %d status events for the rejected change, want none", events)
+	}
+}
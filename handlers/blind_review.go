@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/resume"
+	"job-api/utils"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RevealApplicationRequest struct {
+	Reason string `json:"reason" validate:"required,min=1,max=500"`
+}
+
+type blindReview struct {
+	job    models.Job
+	reveal *models.PipelineStage
+	stages map[uuid.UUID]models.PipelineStage
+}
+
+func loadBlindReview(job models.Job) blindReview {
+	review := blindReview{job: job, stages: make(map[uuid.UUID]models.PipelineStage)}
+	if !job.BlindReview {
+		return review
+	}
+
+	// Without the pipeline no stage counts as passed, so everyone stays hidden
+	pipeline, err := loadJobPipeline(job)
+	if err != nil {
+		return review
+	}
+	for _, stage := range pipeline.Stages {
+		review.stages[stage.ID] = stage
+	}
+	if job.BlindRevealStageID != nil {
+		if stage, ok := review.stages[*job.BlindRevealStageID]; ok {
+			review.reveal = &stage
+		}
+	}
+	return review
+}
+
+func (r blindReview) hidden(application models.Application) bool {
+	if !r.job.BlindReview || application.RevealedAt != nil {
+		return false
+	}
+	if application.StageID == nil {
+		return true
+	}
+	return !r.passed(r.stages[*application.StageID])
+}
+
+// Hired stages are always past the reveal stage, rejected stages never.
+func (r blindReview) passed(stage models.PipelineStage) bool {
+	switch stage.Category {
+	case models.StageCategoryHired:
+		return true
+	case models.StageCategoryActive:
+		return r.reveal != nil && stage.Position > r.reveal.Position
+	}
+	return false
+}
+
+// The redacted application must not be saved.
+func redactApplication(application *models.Application) {
+	terms := utils.NameTerms(application.Applicant.Name)
+	application.Applicant = models.User{Name: blindAlias(*application), Role: models.RoleApplicant}
+	application.ApplicantID = uuid.Nil
+	application.ResumeLink = ""
+	application.ResumeFileID = nil
+	application.CoverLetter = utils.RedactText(application.CoverLetter, terms)
+	if application.ParsedResume != nil {
+		redactParsedResume(application.ParsedResume, terms)
+	}
+	application.Redacted = true
+}
+
+func redactParsedResume(parsed *models.ParsedResume, terms []string) {
+	terms = append(terms, utils.NameTerms(parsed.Contact.Name)...)
+	terms = append(terms, parsed.Contact.Email, parsed.Contact.Phone)
+	terms = append(terms, parsed.Contact.Links...)
+	parsed.Text = utils.RedactText(parsed.Text, terms)
+	parsed.Contact = resume.Contact{}
+	parsed.OwnerID = uuid.Nil
+	parsed.FileID = uuid.Nil
+}
+
+// redactMessages needs the application's Applicant loaded.
+func redactMessages(messages []models.Message, application models.Application) {
+	terms := append(utils.NameTerms(application.Applicant.Name), application.Applicant.Email)
+	for i := range messages {
+		messages[i].Body = utils.RedactText(messages[i].Body, terms)
+		if messages[i].SenderID != application.ApplicantID {
+			continue
+		}
+		messages[i].SenderID = uuid.Nil
+		messages[i].Sender = models.User{Name: blindAlias(application), Role: models.RoleApplicant}
+		for j := range messages[i].Attachments {
+			attachment := &messages[i].Attachments[j]
+			attachment.OwnerID = uuid.Nil
+			attachment.FileName = "attachment" + filepath.Ext(attachment.FileName)
+		}
+	}
+}
+
+// Aliases are random rather than derived from the ID, which the team sees.
+func blindAlias(application models.Application) string {
+	return "Candidate " + application.BlindAlias
+}
+
+// applicationVisible follows the same rules as blindReview.hidden.
+const applicationVisible = `(applications.revealed_at IS NOT NULL OR NOT EXISTS (
+	SELECT 1 FROM jobs WHERE jobs.id = applications.job_id AND jobs.blind_review
+) OR EXISTS (
+	SELECT 1 FROM pipeline_stages stage
+	JOIN jobs ON jobs.id = applications.job_id
+	LEFT JOIN pipeline_stages reveal ON reveal.id = jobs.blind_reveal_stage_id
+	WHERE stage.id = applications.stage_id AND (stage.category = 'hired' OR
+		(stage.category = 'active' AND reveal.id IS NOT NULL AND stage.position > reveal.position))
+))`
+
+func applicationHidden(application models.Application) bool {
+	job := application.Job
+	if job.ID == uuid.Nil {
+		if err := config.DB.First(&job, application.JobID).Error; err != nil {
+			return true
+		}
+	}
+	return loadBlindReview(job).hidden(application)
+}
+
+// applicantDisplayName needs the application's Applicant loaded.
+func applicantDisplayName(application models.Application) string {
+	if applicationHidden(application) {
+		return blindAlias(application)
+	}
+	return application.Applicant.Name
+}
+
+// revealOnStageChange needs the application's Job loaded.
+func revealOnStageChange(tx *gorm.DB, application models.Application, event models.ApplicationStatusEvent) error {
+	if !application.Job.BlindReview || application.RevealedAt != nil || event.ToStageID == nil {
+		return nil
+	}
+	review := loadBlindReview(application.Job)
+	if !review.passed(review.stages[*event.ToStageID]) {
+		return nil
+	}
+	return revealApplications(tx, []uuid.UUID{application.ID}, event.ActorID, true,
+		"Moved to "+string(event.ToStatus))
+}
+
+func revealApplications(tx *gorm.DB, applicationIDs []uuid.UUID, actorID *uuid.UUID, automatic bool, reason string) error {
+	var hidden []uuid.UUID
+	if err := tx.Model(&models.Application{}).Where("id IN ? AND revealed_at IS NULL", applicationIDs).
+		Pluck("id", &hidden).Error; err != nil {
+		return err
+	}
+	if len(hidden) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&models.Application{}).Where("id IN ?", hidden).
+		UpdateColumn("revealed_at", time.Now()).Error; err != nil {
+		return err
+	}
+	reveals := make([]models.ApplicationReveal, len(hidden))
+	for i, id := range hidden {
+		reveals[i] = models.ApplicationReveal{ApplicationID: id, ActorID: actorID, Automatic: automatic, Reason: reason}
+	}
+	return tx.Create(&reveals).Error
+}
+
+func validateBlindReview(pipeline models.Pipeline, enabled bool, revealStageID *uuid.UUID) error {
+	if !enabled {
+		return nil
+	}
+	if revealStageID == nil {
+		return errors.New("blind_reveal_stage_id is required when blind_review is enabled")
+	}
+	stage, ok := pipeline.FindStage(revealStageID, "")
+	if !ok {
+		return errors.New("blind_reveal_stage_id must be a stage of the job's pipeline")
+	}
+	if stage.Category != models.StageCategoryActive {
+		return errors.New("blind_reveal_stage_id must be an active stage")
+	}
+	return nil
+}
+
+func RevealApplication(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req RevealApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	application, err := findTeamApplication(appUUID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	if !loadBlindReview(application.Job).hidden(application) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Applicant is not hidden",
+			Object:  nil,
+		})
+		return
+	}
+
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		return revealApplications(tx, []uuid.UUID{application.ID}, &currentUserID, false, strings.TrimSpace(req.Reason))
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to reveal applicant",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	config.DB.Preload("Applicant").Preload("Stage").First(&application, application.ID)
+	if !canSeeRatings(currentUserID, application) {
+		hideRatings(&application)
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Applicant revealed successfully",
+		Object:  application,
+	})
+}
+
+func GetApplicationReveals(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	application, err := findTeamApplication(appUUID, userID.(uuid.UUID))
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var reveals []models.ApplicationReveal
+	if err := config.DB.Preload("Actor").Where("application_id = ?", application.ID).
+		Order("created_at ASC").Find(&reveals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch reveal log",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Reveal log retrieved successfully",
+		Object:  reveals,
+	})
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"job-api/config"
+	"job-api/models"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetJobApplicationsBlindReview(t *testing.T) {
+	setupTestDB(t)
+	company := createTestUser(t, "Acme", models.RoleCompany)
+	applicant := createTestUser(t, "Jane", models.RoleApplicant)
+
+	pipeline, err := models.DefaultPipeline(config.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reveal, _ := pipeline.FindStage(nil, string(models.StatusReviewed))
+	job := createTestJob(t, company, models.Job{BlindReview: true, BlindRevealStageID: &reveal.ID})
+	application := createTestApplication(t, job, applicant, "Jane here, reach me at "+applicant.Email+" or +1 555 123 4567")
+	config.DB.First(&application, application.ID)
+
+	type listed struct {
+		ID            string `json:"id"`
+		ApplicantName string `json:"applicant_name"`
+		ResumeLink    string `json:"resume_link"`
+		CoverLetter   string `json:"cover_letter"`
+		Redacted      bool   `json:"redacted"`
+	}
+	list := func(query string) ([]listed, int64, string) {
+		recorder := serveTest(t, GetJobApplications, http.MethodGet, "/jobs/:id/applications",
+			"/jobs/"+job.ID.String()+"/applications"+query, company, nil)
+		expectStatus(t, recorder, http.StatusOK)
+		var response struct {
+			Object    []listed `json:"object"`
+			TotalSize int64    `json:"total_size"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		return response.Object, response.TotalSize, recorder.Body.String()
+	}
+
+	t.Run("listing", func(t *testing.T) {
+		applications, _, body := list("")
+		if len(applications) != 1 {
+			t.Fatalf("listed %d applications, want 1", len(applications))
+		}
+		got := applications[0]
+		if !got.Redacted || got.ApplicantName != "Candidate "+application.BlindAlias {
+			t.Errorf("listed as %q (redacted %v), want the alias Candidate %s", got.ApplicantName, got.Redacted, application.BlindAlias)
+		}
+		if got.ResumeLink != "" {
+			t.Errorf("resume_link = %q, want it left out", got.ResumeLink)
+		}
+		if got.CoverLetter != "[redacted] here, reach me at [redacted] or [redacted]" {
+			t.Errorf("cover_letter = %q, want names and contact details redacted", got.CoverLetter)
+		}
+		if strings.Contains(body, applicant.ID.String()) || strings.Contains(strings.ToLower(body), "jane") {
+			t.Errorf("response identifies the applicant: %s", body)
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		for _, query := range []string{"?q=Jane", "?q=" + applicant.Email} {
+			applications, total, _ := list(query)
+			if len(applications) != 0 || total != 0 {
+				t.Errorf("%s matched %d hidden applications, want none", query, total)
+			}
+		}
+	})
+}
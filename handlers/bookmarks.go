@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"job-api/config"
+	"job-api/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func BookmarkJob(c *gin.Context) {
+	jobID := c.Param("id")
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var job models.Job
+	if err := config.DB.First(&job, jobUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Job not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	var existingBookmark models.Bookmark
+	if err := config.DB.Where("user_id = ? AND job_id = ?", currentUserID, jobUUID).
+		First(&existingBookmark).Error; err == nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Job already bookmarked",
+			Object:  nil,
+			Errors:  []string{"Duplicate bookmark"},
+		})
+		return
+	}
+
+	bookmark := models.Bookmark{
+		UserID: currentUserID,
+		JobID:  jobUUID,
+	}
+
+	if err := config.DB.Create(&bookmark).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to bookmark job",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Job bookmarked successfully",
+		Object:  bookmark,
+	})
+}
+
+func RemoveBookmark(c *gin.Context) {
+	jobID := c.Param("id")
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	result := config.DB.Where("user_id = ? AND job_id = ?", currentUserID, jobUUID).Delete(&models.Bookmark{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to remove bookmark",
+			Object:  nil,
+			Errors:  []string{result.Error.Error()},
+		})
+		return
+	}
+
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Bookmark not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Bookmark removed successfully",
+		Object:  nil,
+	})
+}
+
+func GetMyBookmarks(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var total int64
+	config.DB.Model(&models.Bookmark{}).Where("user_id = ?", currentUserID).Count(&total)
+
+	var bookmarks []models.Bookmark
+	if err := config.DB.Where("user_id = ?", currentUserID).
+		Preload("Job").Preload("Job.Creator").
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).Find(&bookmarks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch bookmarks",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Success:    true,
+		Message:    "Bookmarks retrieved successfully",
+		Object:     bookmarks,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  total,
+	})
+}
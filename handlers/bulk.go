@@ -0,0 +1,497 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/queue"
+	"job-api/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Bulk actions
+const (
+	BulkActionMove   = "move"
+	BulkActionReject = "reject"
+	BulkActionTag    = "tag"
+	BulkActionUntag  = "untag"
+	BulkActionAssign = "assign"
+)
+
+const (
+	taskBulkApplications = "applications.bulk"
+
+	// Larger batches run in the background
+	bulkInlineLimit = 50
+)
+
+type BulkApplicationActionRequest struct {
+	ApplicationIDs    []uuid.UUID              `json:"application_ids" validate:"required,min=1,max=2000"`
+	Action            string                   `json:"action" validate:"required,oneof=move reject tag untag assign"`
+	StageID           *uuid.UUID               `json:"stage_id"`
+	Status            models.ApplicationStatus `json:"status" validate:"max=100"`
+	Reason            string                   `json:"reason" validate:"max=500"`
+	MessageTemplateID *uuid.UUID               `json:"message_template_id"`
+	Tags              []string                 `json:"tags" validate:"max=20,dive,required,max=50"`
+	AssigneeID        *uuid.UUID               `json:"assignee_id"`
+}
+
+type bulkAction struct {
+	BulkApplicationActionRequest
+	ActorID  uuid.UUID
+	orgID    uuid.UUID
+	template *models.MessageTemplate
+	tags     []models.Tag
+}
+
+type bulkTask struct {
+	OperationID uuid.UUID `json:"operation_id"`
+}
+
+func BulkUpdateApplications(c *gin.Context) {
+	var req BulkApplicationActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	req.ApplicationIDs = uniqueIDs(req.ApplicationIDs)
+
+	action, err := prepareBulkAction(req, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	request, _ := json.Marshal(req)
+	operation := models.BulkOperation{
+		OrganizationID: organizationID(currentUserID),
+		CreatedBy:      currentUserID,
+		Action:         req.Action,
+		Request:        request,
+		Status:         models.BulkOperationQueued,
+		Total:          len(req.ApplicationIDs),
+	}
+
+	if len(req.ApplicationIDs) > bulkInlineLimit {
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&operation).Error; err != nil {
+				return err
+			}
+			return queue.Enqueue(tx, taskBulkApplications, bulkTask{OperationID: operation.ID})
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.BaseResponse{
+				Success: false,
+				Message: "Failed to queue bulk action",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, models.BaseResponse{
+			Success: true,
+			Message: "Bulk action queued",
+			Object:  operation,
+		})
+		return
+	}
+
+	operation.Status = models.BulkOperationRunning
+	if err := config.DB.Create(&operation).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to start bulk action",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	if err := runBulkOperation(context.Background(), &operation, action); err != nil {
+		failBulkOperation(&operation, err)
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to record bulk action results",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Bulk action completed",
+		Object:  operation,
+	})
+}
+
+func GetBulkOperation(c *gin.Context) {
+	operationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid bulk operation ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	var operation models.BulkOperation
+	if err := config.DB.Where("id = ? AND organization_id = ?", operationUUID, organizationID(userID.(uuid.UUID))).
+		First(&operation).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Bulk operation not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Bulk operation retrieved successfully",
+		Object:  operation,
+	})
+}
+
+func bulkApplicationsTask(ctx context.Context, task models.QueueTask) error {
+	var payload bulkTask
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return queue.Permanent(err)
+	}
+
+	var operation models.BulkOperation
+	if err := config.DB.First(&operation, payload.OperationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if operation.Status == models.BulkOperationCompleted || operation.Status == models.BulkOperationFailed {
+		return nil
+	}
+
+	var req BulkApplicationActionRequest
+	err := json.Unmarshal(operation.Request, &req)
+	var action bulkAction
+	if err == nil {
+		action, err = prepareBulkAction(req, operation.CreatedBy)
+	}
+	if err != nil {
+		// The request was valid when queued, so something it refers to is gone
+		failBulkOperation(&operation, err)
+		return queue.Permanent(err)
+	}
+
+	if err := config.DB.Model(&operation).Update("status", models.BulkOperationRunning).Error; err != nil {
+		return err
+	}
+	return runBulkOperation(ctx, &operation, action)
+}
+
+// Each result is saved in the transaction that applies the action, so a
+// retried run skips the applications that already have one.
+func runBulkOperation(ctx context.Context, operation *models.BulkOperation, action bulkAction) error {
+	done := make(map[uuid.UUID]bool, len(operation.Results))
+	for _, result := range operation.Results {
+		done[result.ApplicationID] = true
+	}
+
+	for _, id := range action.ApplicationIDs {
+		if done[id] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		result := models.BulkItemResult{ApplicationID: id, Success: true}
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := lockBulkItem(tx, operation.ID, id); err != nil {
+				return err
+			}
+			if err := action.apply(tx, id); err != nil {
+				return err
+			}
+			return recordBulkResult(tx, operation, result)
+		})
+		if errors.Is(err, errBulkItemDone) {
+			continue
+		}
+		if err != nil {
+			result = models.BulkItemResult{ApplicationID: id, Error: err.Error()}
+			err = config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockBulkItem(tx, operation.ID, id); err != nil {
+					return err
+				}
+				return recordBulkResult(tx, operation, result)
+			})
+			if errors.Is(err, errBulkItemDone) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		operation.Results = append(operation.Results, result)
+		operation.Processed++
+		if result.Success {
+			operation.Succeeded++
+		} else {
+			operation.Failed++
+		}
+	}
+
+	now := time.Now()
+	operation.Status = models.BulkOperationCompleted
+	operation.CompletedAt = &now
+	return config.DB.Model(operation).Select("status", "completed_at").Updates(operation).Error
+}
+
+var errBulkItemDone = errors.New("application already has a result")
+
+// Locking the operation row makes other runs of it wait for this item's result.
+func lockBulkItem(tx *gorm.DB, operationID, applicationID uuid.UUID) error {
+	encoded, err := json.Marshal([]map[string]uuid.UUID{{"application_id": applicationID}})
+	if err != nil {
+		return err
+	}
+	var done []bool
+	if err := tx.Raw(`SELECT COALESCE(results, '[]'::jsonb) @> ?::jsonb FROM bulk_operations WHERE id = ? FOR UPDATE`,
+		string(encoded), operationID).Scan(&done).Error; err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		return errors.New("bulk operation was deleted")
+	}
+	if done[0] {
+		return errBulkItemDone
+	}
+	return nil
+}
+
+func recordBulkResult(tx *gorm.DB, operation *models.BulkOperation, result models.BulkItemResult) error {
+	succeeded, failed := 1, 0
+	if !result.Success {
+		succeeded, failed = 0, 1
+	}
+	encoded, err := json.Marshal([]models.BulkItemResult{result})
+	if err != nil {
+		return err
+	}
+	return tx.Exec(`UPDATE bulk_operations SET results = COALESCE(results, '[]'::jsonb) || ?::jsonb,
+		processed = processed + 1, succeeded = succeeded + ?, failed = failed + ?, updated_at = ?
+		WHERE id = ?`, string(encoded), succeeded, failed, time.Now(), operation.ID).Error
+}
+
+func failBulkOperation(operation *models.BulkOperation, err error) {
+	now := time.Now()
+	operation.Status = models.BulkOperationFailed
+	operation.Error = err.Error()
+	operation.CompletedAt = &now
+	config.DB.Model(operation).Select("status", "error", "completed_at").Updates(operation)
+}
+
+func prepareBulkAction(req BulkApplicationActionRequest, actorID uuid.UUID) (bulkAction, error) {
+	orgID := organizationID(actorID)
+	action := bulkAction{BulkApplicationActionRequest: req, ActorID: actorID, orgID: orgID}
+
+	switch req.Action {
+	case BulkActionMove:
+		if req.StageID == nil && req.Status == "" {
+			return action, errors.New("move needs a stage_id or status")
+		}
+	case BulkActionReject:
+		if req.MessageTemplateID != nil {
+			template, err := findMessageTemplate(*req.MessageTemplateID, orgID)
+			if err != nil {
+				return action, err
+			}
+			action.template = &template
+		}
+	case BulkActionTag, BulkActionUntag:
+		if len(req.Tags) == 0 {
+			return action, errors.New(req.Action + " needs at least one tag")
+		}
+		tags, err := findTags(config.DB, orgID, req.Tags, req.Action == BulkActionTag, actorID)
+		if err != nil {
+			return action, err
+		}
+		action.tags = tags
+	case BulkActionAssign:
+		if req.AssigneeID != nil {
+			users, err := organizationUsers(orgID)
+			if err != nil {
+				return action, err
+			}
+			member := false
+			for _, user := range users {
+				member = member || user.ID == *req.AssigneeID
+			}
+			if !member {
+				return action, errors.New("assignee must be a member of your organization")
+			}
+		}
+	}
+	return action, nil
+}
+
+func (a bulkAction) apply(tx *gorm.DB, applicationID uuid.UUID) error {
+	var application models.Application
+	if err := tx.Preload("Applicant").Preload("Job.Creator").First(&application, applicationID).Error; err != nil {
+		return errApplicationNotFound
+	}
+	if application.Job.OrganizationID != a.orgID {
+		return errNotHiringTeam
+	}
+
+	switch a.Action {
+	case BulkActionMove, BulkActionReject:
+		return a.changeStatus(tx, application)
+	case BulkActionTag:
+		return tagApplication(tx, application.ID, a.tags)
+	case BulkActionUntag:
+		var tagIDs []uuid.UUID
+		for _, tag := range a.tags {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+		return tx.Exec("DELETE FROM application_tags WHERE application_id = ? AND tag_id IN ?", application.ID, tagIDs).Error
+	case BulkActionAssign:
+		return tx.Model(&application).UpdateColumn("assignee_id", a.AssigneeID).Error
+	}
+	return errors.New("unknown action " + a.Action)
+}
+
+func (a bulkAction) changeStatus(tx *gorm.DB, application models.Application) error {
+	if application.Status == models.StatusWithdrawn {
+		return errors.New("application has been withdrawn by the applicant")
+	}
+
+	pipeline, err := loadJobPipeline(application.Job)
+	if err != nil {
+		return err
+	}
+
+	var target models.PipelineStage
+	var ok bool
+	if a.Action == BulkActionReject {
+		target, ok = pipeline.RejectedStage()
+	} else {
+		target, ok = pipeline.FindStage(a.StageID, string(a.Status))
+	}
+	if !ok {
+		return errors.New("stage not found in the job's pipeline")
+	}
+
+	current, ok := pipeline.FindStage(application.StageID, string(application.Status))
+	if !ok {
+		return errors.New("application is not in a stage of the job pipeline")
+	}
+	if err := pipeline.ValidateTransition(current, target); err != nil {
+		return err
+	}
+
+	event := models.ApplicationStatusEvent{
+		ApplicationID: application.ID,
+		FromStatus:    application.Status,
+		ToStatus:      models.ApplicationStatus(target.Name),
+		FromStageID:   &current.ID,
+		ToStageID:     &target.ID,
+		ActorID:       &a.ActorID,
+		Reason:        a.Reason,
+	}
+
+	var message *models.Message
+	if a.template != nil {
+		body, err := renderMessageTemplate(*a.template, application, a.ActorID)
+		if err != nil {
+			return err
+		}
+		message = &models.Message{
+			ApplicationID: application.ID,
+			SenderID:      a.ActorID,
+			Body:          body,
+			TemplateID:    &a.template.ID,
+		}
+	}
+
+	if err := changeApplicationStatus(tx, application, event); err != nil {
+		return err
+	}
+	if message == nil {
+		return nil
+	}
+	return recordMessage(tx, message, application, true, nil)
+}
+
+// findTags matches names ignoring case. With create, missing tags are added.
+func findTags(tx *gorm.DB, orgID uuid.UUID, names []string, create bool, createdBy uuid.UUID) ([]models.Tag, error) {
+	var tags []models.Tag
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+
+		var tag models.Tag
+		err := tx.Where("organization_id = ? AND LOWER(name) = ?", orgID, strings.ToLower(name)).First(&tag).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) && create {
+			tag = models.Tag{OrganizationID: orgID, Name: name, CreatedBy: createdBy}
+			err = tx.Create(&tag).Error
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("tag " + name + " not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var unique []uuid.UUID
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"job-api/config"
+	"job-api/mailer"
+	"job-api/models"
+	"job-api/queue"
+	"job-api/utils"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// emailTimeFormat is how dates appear in notification emails.
+const emailTimeFormat = "Mon, 02 Jan 2006 15:04 MST"
+
+// The template shares the notification type's name.
+type notificationEmail struct {
+	UserID      uuid.UUID               `json:"user_id"`
+	Type        models.NotificationType `json:"type"`
+	Data        map[string]string       `json:"data"`
+	Attachments []mailer.Attachment     `json:"attachments"`
+}
+
+// Call inside the transaction making the change the emails describe.
+func queueNotificationEmails(tx *gorm.DB, emails ...notificationEmail) error {
+	for _, email := range emails {
+		if err := queue.Enqueue(tx, taskSendEmail, email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendEmailTask sends a queued notification email.
+func sendEmailTask(ctx context.Context, task models.QueueTask) error {
+	var email notificationEmail
+	if err := json.Unmarshal(task.Payload, &email); err != nil {
+		return queue.Permanent(err)
+	}
+	return deliverNotificationEmail(ctx, email)
+}
+
+func deliverNotificationEmail(ctx context.Context, email notificationEmail) error {
+	if config.Mailer == nil {
+		return errors.New("mailer is not configured")
+	}
+
+	var user models.User
+	if err := config.DB.First(&user, email.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return queue.Permanent(err)
+		}
+		return err
+	}
+
+	settings := loadNotificationSettings(user.ID)
+	if !settings.EmailEnabled || !emailPreferenceEnabled(user.ID, email.Type) {
+		return nil
+	}
+
+	data := map[string]string{
+		"name":            user.Name,
+		"site_name":       feedPublisher(),
+		"unsubscribe_url": unsubscribeURL(user.ID, email.Type),
+	}
+	for key, value := range email.Data {
+		data[key] = value
+	}
+
+	subject, body, err := mailer.Render(settings.Locale, string(email.Type), data)
+	if err != nil {
+		return queue.Permanent(err)
+	}
+
+	return config.Mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		ToName:  user.Name,
+		Subject: subject,
+		Text:    body,
+		Headers: map[string]string{
+			// RFC 8058 one-click unsubscribe
+			"List-Unsubscribe":      "<" + data["unsubscribe_url"] + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+		Attachments: email.Attachments,
+	})
+}
+
+// applicationEmailData holds the fields shared by emails about an application.
+func applicationEmailData(application models.Application, job models.Job) map[string]string {
+	var company models.User
+	config.DB.First(&company, job.CreatedBy)
+	return map[string]string{
+		"applicant_name": application.Applicant.Name,
+		"job_title":      job.Title,
+		"company_name":   company.Name,
+		"status":         string(application.Status),
+		"applied_at":     formatEmailTime(application.AppliedAt),
+	}
+}
+
+func loadNotificationSettings(userID uuid.UUID) models.NotificationSettings {
+	settings := models.NotificationSettings{UserID: userID, Locale: mailer.DefaultLocale, EmailEnabled: true}
+	config.DB.Where("user_id = ?", userID).Limit(1).Find(&settings)
+	return settings
+}
+
+func emailPreferenceEnabled(userID uuid.UUID, notificationType models.NotificationType) bool {
+	var preference models.NotificationPreference
+	if err := config.DB.Where("user_id = ? AND type = ?", userID, notificationType).First(&preference).Error; err != nil {
+		return true
+	}
+	return preference.Email
+}
+
+// Mail clients POST to the same URL for one-click unsubscribe.
+func unsubscribeURL(userID uuid.UUID, notificationType models.NotificationType) string {
+	query := url.Values{}
+	query.Set("user", userID.String())
+	query.Set("type", string(notificationType))
+	query.Set("signature", utils.SignUnsubscribe(userID, string(notificationType)))
+	return config.PublicBaseURL + "/unsubscribe?" + query.Encode()
+}
+
+func formatEmailTime(t time.Time) string {
+	return t.UTC().Format(emailTimeFormat)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"job-api/realtime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+)
+
+const (
+	eventHeartbeatInterval = 25 * time.Second
+	eventReplayLimit       = 500
+)
+
+// Realtime event types
+const (
+	EventApplicationCreated       = "application.created"
+	EventApplicationStatusChanged = "application.status_changed"
+	EventMessageCreated           = "message.created"
+	EventNotificationCreated      = "notification.created"
+)
+
+func StreamEvents(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	lastID := lastEventID(c)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	streamEvents(c.Request.Context(), userID.(uuid.UUID), lastID,
+		func(event models.RealtimeEvent) error {
+			_, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", *event.Seq, event.Type, event.Data)
+			c.Writer.Flush()
+			return err
+		},
+		func() error {
+			_, err := fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+			return err
+		},
+	)
+}
+
+func StreamEventsWebSocket(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	lastID := lastEventID(c)
+
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		// Clients only send control frames; a failed read means they went away
+		go func() {
+			var discard []byte
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+			cancel()
+		}()
+
+		streamEvents(ctx, userID.(uuid.UUID), lastID,
+			func(event models.RealtimeEvent) error {
+				return websocket.JSON.Send(ws, event)
+			},
+			func() error {
+				return websocket.Message.Send(ws, `{"type":"heartbeat"}`)
+			},
+		)
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func streamEvents(ctx context.Context, userID uuid.UUID, lastSeq int64, send func(models.RealtimeEvent) error, heartbeat func() error) {
+	// Subscribe before replaying so nothing published in between is lost
+	sub := config.Broker.Subscribe(userID)
+	defer config.Broker.Unsubscribe(sub)
+
+	replayed := make(map[int64]bool)
+	if lastSeq > 0 {
+		events, err := realtime.Since(config.DB, userID, lastSeq, eventReplayLimit)
+		if err != nil {
+			return
+		}
+		for _, event := range events {
+			if send(event) != nil {
+				return
+			}
+			replayed[event.ID] = true
+		}
+	}
+
+	ticker := time.NewTicker(eventHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			if send(event) != nil {
+				return
+			}
+		case <-ticker.C:
+			if heartbeat() != nil {
+				return
+			}
+		}
+	}
+}
+
+func lastEventID(c *gin.Context) int64 {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	id, _ := strconv.ParseInt(value, 10, 64)
+	return id
+}
+
+func publishApplicationEvent(tx *gorm.DB, eventType string, application models.Application, job models.Job, data map[string]interface{}) error {
+	team, err := organizationUsers(job.OrganizationID)
+	if err != nil {
+		return err
+	}
+	data["application_id"] = application.ID
+	data["job_id"] = job.ID
+	if err := realtime.Publish(tx, eventType, append(userIDs(team), application.ApplicantID), data); err != nil {
+		return err
+	}
+	return enqueueWebhooks(tx, job.OrganizationID, eventType, data)
+}
@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultFeedItems = 50
+	maxFeedItems     = 500
+	feedFlushEvery   = 100
+)
+
+type feedJob struct {
+	ID             uuid.UUID
+	Title          string
+	Description    string
+	Location       string
+	EmploymentType string
+	SalaryMin      *float64
+	SalaryMax      *float64
+	SalaryCurrency string
+	SalaryPeriod   string
+	CreatedBy      uuid.UUID
+	CompanyName    string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type atomLink struct {
+	XMLName xml.Name `xml:"link"`
+	Href    string   `xml:"href,attr"`
+	Rel     string   `xml:"rel,attr,omitempty"`
+	Type    string   `xml:"type,attr,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	XMLName   xml.Name `xml:"entry"`
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+	Author    string   `xml:"author>name"`
+	Content   atomText `xml:"content"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	XMLName     xml.Name `xml:"item"`
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Category    string   `xml:"category,omitempty"`
+	Description string   `xml:"description"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+type aggregatorJob struct {
+	XMLName         xml.Name `xml:"job"`
+	Title           cdata    `xml:"title"`
+	Date            cdata    `xml:"date"`
+	ReferenceNumber cdata    `xml:"referencenumber"`
+	URL             cdata    `xml:"url"`
+	Company         cdata    `xml:"company"`
+	City            cdata    `xml:"city"`
+	Description     cdata    `xml:"description"`
+	Salary          cdata    `xml:"salary"`
+	JobType         cdata    `xml:"jobtype"`
+	Updated         cdata    `xml:"updated"`
+}
+
+func GetJobsAtomFeed(c *gin.Context) {
+	streamFeed(c, "atom", uuid.Nil)
+}
+
+func GetJobsRSSFeed(c *gin.Context) {
+	streamFeed(c, "rss", uuid.Nil)
+}
+
+func GetCompanyJobsAtomFeed(c *gin.Context) {
+	if companyID, ok := parseCompanyID(c); ok {
+		streamFeed(c, "atom", companyID)
+	}
+}
+
+func GetCompanyJobsRSSFeed(c *gin.Context) {
+	if companyID, ok := parseCompanyID(c); ok {
+		streamFeed(c, "rss", companyID)
+	}
+}
+
+// The XML format used by Indeed, Jooble and similar aggregators.
+func ExportJobsXML(c *gin.Context) {
+	query := feedQuery(c, uuid.Nil)
+
+	lastModified, notModified := checkFeedModified(c)
+	if notModified {
+		return
+	}
+
+	rows, err := query.Order("jobs.created_at DESC").Rows()
+	if err != nil {
+		c.String(http.StatusInternalServerError, "failed to build feed")
+		return
+	}
+	defer rows.Close()
+
+	writeFeedHeaders(c, "application/xml; charset=utf-8", lastModified)
+	encoder := xml.NewEncoder(c.Writer)
+	encoder.Indent("", "  ")
+
+	c.Writer.WriteString(xml.Header)
+	root := xml.StartElement{Name: xml.Name{Local: "source"}}
+	encoder.EncodeToken(root)
+	encoder.Encode(struct {
+		XMLName xml.Name `xml:"publisher"`
+		Value   string   `xml:",chardata"`
+	}{Value: feedPublisher()})
+	encoder.Encode(struct {
+		XMLName xml.Name `xml:"publisherurl"`
+		Value   string   `xml:",chardata"`
+	}{Value: config.PublicBaseURL})
+	encoder.Encode(struct {
+		XMLName xml.Name `xml:"lastBuildDate"`
+		Value   string   `xml:",chardata"`
+	}{Value: time.Now().UTC().Format(time.RFC1123Z)})
+
+	err = streamFeedRows(c, rows, encoder, func(job feedJob) {
+		encoder.Encode(aggregatorJob{
+			Title:           cdata{job.Title},
+			Date:            cdata{job.CreatedAt.UTC().Format(time.RFC1123Z)},
+			ReferenceNumber: cdata{job.ID.String()},
+			URL:             cdata{publicJobURL(job.ID)},
+			Company:         cdata{job.CompanyName},
+			City:            cdata{job.Location},
+			Description:     cdata{models.DescriptionHTML(job.Description)},
+			Salary:          cdata{salaryText(job)},
+			JobType:         cdata{strings.ToLower(strings.ReplaceAll(job.EmploymentType, "_", "-"))},
+			Updated:         cdata{job.UpdatedAt.UTC().Format(time.RFC1123Z)},
+		})
+	})
+	if err != nil {
+		log.Println("Failed to stream jobs export:", err)
+		return
+	}
+
+	encoder.EncodeToken(root.End())
+	encoder.Flush()
+}
+
+func streamFeed(c *gin.Context, format string, companyID uuid.UUID) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultFeedItems)))
+	if limit < 1 || limit > maxFeedItems {
+		limit = defaultFeedItems
+	}
+
+	query := feedQuery(c, companyID)
+
+	lastModified, notModified := checkFeedModified(c)
+	if notModified {
+		return
+	}
+
+	rows, err := query.Order("jobs.created_at DESC").Limit(limit).Rows()
+	if err != nil {
+		c.String(http.StatusInternalServerError, "failed to build feed")
+		return
+	}
+	defer rows.Close()
+
+	selfURL := config.PublicBaseURL + c.Request.URL.RequestURI()
+	title := "Open jobs"
+	if companyID != uuid.Nil {
+		var company models.User
+		if err := config.DB.Select("id", "name").First(&company, companyID).Error; err == nil {
+			title = "Open jobs at " + company.Name
+		}
+	}
+	if lastModified.IsZero() {
+		lastModified = time.Now()
+	}
+
+	encoder := xml.NewEncoder(c.Writer)
+	encoder.Indent("", "  ")
+
+	var root xml.StartElement
+	if format == "atom" {
+		writeFeedHeaders(c, "application/atom+xml; charset=utf-8", lastModified)
+		c.Writer.WriteString(xml.Header)
+		root = xml.StartElement{
+			Name: xml.Name{Local: "feed"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: "http://www.w3.org/2005/Atom"}},
+		}
+		encoder.EncodeToken(root)
+		encoder.EncodeElement(selfURL, xml.StartElement{Name: xml.Name{Local: "id"}})
+		encoder.EncodeElement(title, xml.StartElement{Name: xml.Name{Local: "title"}})
+		encoder.EncodeElement(lastModified.UTC().Format(time.RFC3339), xml.StartElement{Name: xml.Name{Local: "updated"}})
+		encoder.Encode(atomLink{Href: selfURL, Rel: "self", Type: "application/atom+xml"})
+	} else {
+		writeFeedHeaders(c, "application/rss+xml; charset=utf-8", lastModified)
+		c.Writer.WriteString(xml.Header)
+		root = xml.StartElement{Name: xml.Name{Local: "rss"}, Attr: []xml.Attr{{Name: xml.Name{Local: "version"}, Value: "2.0"}}}
+		encoder.EncodeToken(root)
+		encoder.EncodeToken(xml.StartElement{Name: xml.Name{Local: "channel"}})
+		encoder.EncodeElement(title, xml.StartElement{Name: xml.Name{Local: "title"}})
+		encoder.EncodeElement(config.PublicBaseURL, xml.StartElement{Name: xml.Name{Local: "link"}})
+		encoder.EncodeElement(title+" from "+feedPublisher(), xml.StartElement{Name: xml.Name{Local: "description"}})
+		encoder.EncodeElement(lastModified.UTC().Format(time.RFC1123Z), xml.StartElement{Name: xml.Name{Local: "lastBuildDate"}})
+	}
+
+	err = streamFeedRows(c, rows, encoder, func(job feedJob) {
+		link := publicJobURL(job.ID)
+		entryTitle := job.Title
+		if job.CompanyName != "" {
+			entryTitle = fmt.Sprintf("%s at %s", job.Title, job.CompanyName)
+		}
+		if job.Location != "" {
+			entryTitle = fmt.Sprintf("%s (%s)", entryTitle, job.Location)
+		}
+
+		if format == "atom" {
+			encoder.Encode(atomEntry{
+				ID:        "urn:uuid:" + job.ID.String(),
+				Title:     entryTitle,
+				Link:      atomLink{Href: link, Rel: "alternate", Type: "text/html"},
+				Published: job.CreatedAt.UTC().Format(time.RFC3339),
+				Updated:   job.UpdatedAt.UTC().Format(time.RFC3339),
+				Author:    job.CompanyName,
+				Content:   atomText{Type: "html", Body: models.DescriptionHTML(job.Description)},
+			})
+		} else {
+			encoder.Encode(rssItem{
+				Title:       entryTitle,
+				Link:        link,
+				GUID:        rssGUID{IsPermaLink: "false", Value: "urn:uuid:" + job.ID.String()},
+				PubDate:     job.CreatedAt.UTC().Format(time.RFC1123Z),
+				Category:    job.Location,
+				Description: models.DescriptionHTML(job.Description),
+			})
+		}
+	})
+	if err != nil {
+		log.Println("Failed to stream feed:", err)
+		return
+	}
+
+	if format == "rss" {
+		encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: "channel"}})
+	}
+	encoder.EncodeToken(root.End())
+	encoder.Flush()
+}
+
+// The status is sent before rows are streamed, so on an error callers leave
+// the document unclosed and readers reject the truncated XML.
+func streamFeedRows(c *gin.Context, rows *sql.Rows, encoder *xml.Encoder, encode func(feedJob)) error {
+	count := 0
+	for rows.Next() {
+		var job feedJob
+		if err := config.DB.ScanRows(rows, &job); err != nil {
+			return err
+		}
+		encode(job)
+
+		count++
+		if count%feedFlushEvery == 0 {
+			encoder.Flush()
+			c.Writer.Flush()
+		}
+	}
+	return rows.Err()
+}
+
+func feedQuery(c *gin.Context, companyID uuid.UUID) *gorm.DB {
+	query := config.DB.Table("jobs").
+		Select("jobs.id, jobs.title, jobs.description, jobs.location, jobs.employment_type, "+
+			"jobs.salary_min, jobs.salary_max, jobs.salary_currency, jobs.salary_period, "+
+			"jobs.created_by, users.name AS company_name, jobs.created_at, jobs.updated_at").
+		Joins("JOIN users ON jobs.created_by = users.id").
+		Scopes(models.PublishedJobs, filterJobs(c))
+
+	if companyID != uuid.Nil {
+		query = query.Where("jobs.created_by = ?", companyID)
+	}
+	return query
+}
+
+// Feeds change when jobs leave them too, so this goes by the whole board.
+func checkFeedModified(c *gin.Context) (time.Time, bool) {
+	lastModified := jobBoardModified()
+	if lastModified.IsZero() {
+		return lastModified, false
+	}
+
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil {
+		if !lastModified.Truncate(time.Second).After(since) {
+			c.Header("Cache-Control", publicCacheControl)
+			c.Status(http.StatusNotModified)
+			return lastModified, true
+		}
+	}
+	return lastModified, false
+}
+
+func writeFeedHeaders(c *gin.Context, contentType string, lastModified time.Time) {
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", publicCacheControl)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	c.Status(http.StatusOK)
+}
+
+func parseCompanyID(c *gin.Context) (uuid.UUID, bool) {
+	companyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid company ID",
+			Object:  nil,
+		})
+		return uuid.Nil, false
+	}
+	return companyID, true
+}
+
+func feedPublisher() string {
+	if publisher := strings.TrimSpace(os.Getenv("FEED_PUBLISHER_NAME")); publisher != "" {
+		return publisher
+	}
+	return "Job API"
+}
+
+func salaryText(job feedJob) string {
+	if job.SalaryCurrency == "" || (job.SalaryMin == nil && job.SalaryMax == nil) {
+		return ""
+	}
+
+	var amount string
+	switch {
+	case job.SalaryMin != nil && job.SalaryMax != nil:
+		amount = fmt.Sprintf("%.0f-%.0f", *job.SalaryMin, *job.SalaryMax)
+	case job.SalaryMin != nil:
+		amount = fmt.Sprintf("from %.0f", *job.SalaryMin)
+	default:
+		amount = fmt.Sprintf("up to %.0f", *job.SalaryMax)
+	}
+
+	if job.SalaryPeriod != "" {
+		return fmt.Sprintf("%s %s per %s", amount, job.SalaryCurrency, strings.ToLower(job.SalaryPeriod))
+	}
+	return amount + " " + job.SalaryCurrency
+}
@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"job-api/config"
+	"job-api/models"
+	"job-api/resume"
+	"job-api/storage"
+	"job-api/utils"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxResumeSize     = 5 << 20
+	maxAttachmentSize = 10 << 20
+	fileURLLifetime   = 5 * time.Minute
+	docxContentType   = resume.DOCXContentType
+)
+
+var resumeContentTypes = map[string]bool{
+	"application/pdf": true,
+	docxContentType:   true,
+	"text/plain":      true,
+}
+
+var attachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	docxContentType:   true,
+	"text/plain":      true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+}
+
+func UploadResume(c *gin.Context) {
+	uploadFile(c, models.FileKindResume, maxResumeSize, resumeContentTypes)
+}
+
+func UploadAttachment(c *gin.Context) {
+	uploadFile(c, models.FileKindAttachment, maxAttachmentSize, attachmentContentTypes)
+}
+
+func uploadFile(c *gin.Context, kind models.FileKind, maxSize int64, allowedTypes map[string]bool) {
+	// Leave room for the multipart envelope around the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{"a file is required in the \"file\" form field"},
+		})
+		return
+	}
+
+	if header.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, models.BaseResponse{
+			Success: false,
+			Message: "File too large",
+			Object:  nil,
+			Errors:  []string{fmt.Sprintf("files may be at most %d MB", maxSize>>20)},
+		})
+		return
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Failed to read file",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxSize+1))
+	if err != nil || int64(len(data)) > maxSize || len(data) == 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Failed to read file",
+			Object:  nil,
+			Errors:  []string{"file is empty or too large"},
+		})
+		return
+	}
+
+	fileName := filepath.Base(header.Filename)
+	contentType := sniffContentType(data, fileName)
+	if !allowedTypes[contentType] {
+		c.JSON(http.StatusUnsupportedMediaType, models.BaseResponse{
+			Success: false,
+			Message: "Unsupported file type",
+			Object:  nil,
+			Errors:  []string{fmt.Sprintf("files of type %s are not accepted", contentType)},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	ownerID := userID.(uuid.UUID)
+
+	sum := sha256.Sum256(data)
+	file := models.File{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Kind:        kind,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+	}
+	file.StorageKey = fmt.Sprintf("%ss/%s/%s", kind, ownerID, file.ID)
+
+	if err := config.Storage.Put(c.Request.Context(), file.StorageKey, bytes.NewReader(data), file.Size, contentType); err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to store file",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := config.DB.Create(&file).Error; err != nil {
+		config.Storage.Delete(c.Request.Context(), file.StorageKey)
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to save file",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if kind == models.FileKindResume {
+		parseUploadedResume(c.Request.Context(), file, data)
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "File uploaded successfully",
+		Object:  file,
+	})
+}
+
+func GetFileURL(c *gin.Context) {
+	fileUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid file ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var file models.File
+	if err := config.DB.First(&file, fileUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "File not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if !canAccessFile(currentUserID, file) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized access",
+			Object:  nil,
+		})
+		return
+	}
+
+	// Anything the applicant uploaded stays hidden while they are
+	if file.OwnerID != currentUserID && file.ApplicationID != nil {
+		var application models.Application
+		if err := config.DB.First(&application, *file.ApplicationID).Error; err != nil ||
+			(file.OwnerID == application.ApplicantID && applicationHidden(application)) {
+			c.JSON(http.StatusForbidden, models.BaseResponse{
+				Success: false,
+				Message: "Files are hidden until the applicant is revealed",
+				Object:  nil,
+			})
+			return
+		}
+	}
+
+	expires := time.Now().Add(fileURLLifetime)
+	url := fmt.Sprintf("%s/files/%s?expires=%d&signature=%s",
+		config.PublicBaseURL, file.ID, expires.Unix(), utils.SignFileURL(file.ID, expires))
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Download URL generated successfully",
+		Object:  gin.H{"url": url, "expires_at": expires},
+	})
+}
+
+// DownloadFile serves a file to holders of a valid signed URL.
+func DownloadFile(c *gin.Context) {
+	fileUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid file ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if !utils.VerifyFileURL(fileUUID, expires, c.Query("signature")) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Invalid or expired download link",
+			Object:  nil,
+		})
+		return
+	}
+
+	var file models.File
+	if err := config.DB.First(&file, fileUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "File not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	body, err := config.Storage.Get(c.Request.Context(), file.StorageKey)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.BaseResponse{
+			Success: false,
+			Message: "Failed to read file",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	defer body.Close()
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, file.Size, file.ContentType, body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}),
+	})
+}
+
+func DeleteFile(c *gin.Context) {
+	fileUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid file ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var file models.File
+	if err := config.DB.First(&file, fileUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "File not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if file.OwnerID != currentUserID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized access",
+			Object:  nil,
+		})
+		return
+	}
+
+	if file.ApplicationID != nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "File is attached to an application",
+			Object:  nil,
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("file_id = ?", file.ID).Delete(&models.ParsedResume{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&file).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete file",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	config.Storage.Delete(c.Request.Context(), file.StorageKey)
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "File deleted successfully",
+		Object:  nil,
+	})
+}
+
+// Applicants can also read message attachments and the terms of sent offers.
+func canAccessFile(userID uuid.UUID, file models.File) bool {
+	if file.OwnerID == userID {
+		return true
+	}
+	if file.ApplicationID == nil {
+		return false
+	}
+
+	var application models.Application
+	if err := config.DB.Preload("Job").First(&application, *file.ApplicationID).Error; err != nil {
+		return false
+	}
+	if file.MessageID != nil && application.ApplicantID == userID {
+		return true
+	}
+	if file.OfferID != nil && application.ApplicantID == userID {
+		var sent int64
+		config.DB.Model(&models.Offer{}).Where("id = ? AND sent_at IS NOT NULL", *file.OfferID).Count(&sent)
+		return sent > 0
+	}
+	return canManageJob(userID, application.Job)
+}
+
+// DOCX files are zip archives and are recognised by their main document part.
+func sniffContentType(data []byte, fileName string) string {
+	detected := strings.TrimSpace(strings.Split(http.DetectContentType(data), ";")[0])
+	if detected != "application/zip" {
+		return detected
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return detected
+	}
+	for _, entry := range archive.File {
+		if entry.Name == "word/document.xml" && strings.EqualFold(filepath.Ext(fileName), ".docx") {
+			return docxContentType
+		}
+	}
+	return detected
+}
+
+func loadApplicantFiles(applicantID uuid.UUID, ids []uuid.UUID) ([]models.File, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ids = uniqueIDs(ids)
+	var files []models.File
+	if err := config.DB.Where("id IN ?", ids).Find(&files).Error; err != nil {
+		return nil, err
+	}
+	if len(files) != len(ids) {
+		return nil, errors.New("one or more files were not found")
+	}
+	for _, file := range files {
+		if file.OwnerID != applicantID {
+			return nil, fmt.Errorf("file %s does not belong to you", file.ID)
+		}
+		if file.ApplicationID != nil {
+			return nil, fmt.Errorf("file %s is already attached to an application", file.ID)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"job-api/config"
+	"job-api/models"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var connectTestDB sync.Once
+
+// setupTestDB points config.DB at the database in TEST_DATABASE_URL and
+// empties it. Tests that need a database are skipped without one.
+func setupTestDB(t *testing.T) {
+	t.Helper()
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	connectTestDB.Do(func() {
+		gin.SetMode(gin.TestMode)
+		os.Setenv("DATABASE_URL", databaseURL)
+		config.ConnectDatabase()
+	})
+
+	var tables []string
+	if err := config.DB.Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema()").
+		Scan(&tables).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := config.DB.Exec("TRUNCATE " + strings.Join(tables, ", ") + " CASCADE").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := models.MigrateDefaultPipeline(config.DB); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createTestUser(t *testing.T, name string, role models.UserRole) models.User {
+	t.Helper()
+	user := models.User{
+		Name:     name,
+		Email:    strings.ToLower(name) + "-" + uuid.NewString()[:8] + "@example.com",
+		Password: "not-a-real-hash",
+		Role:     role,
+	}
+	if err := config.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func createTestJob(t *testing.T, owner models.User, job models.Job) models.Job {
+	t.Helper()
+	pipeline, err := models.DefaultPipeline(config.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Title == "" {
+		job.Title = "Backend Engineer"
+	}
+	if job.Description == "" {
+		job.Description = "Build and run the services behind our hiring platform."
+	}
+	job.PipelineID = &pipeline.ID
+	job.CreatedBy = owner.ID
+	job.OrganizationID = owner.ID
+	if err := config.DB.Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+// createTestApplication adds an application in the first stage of the job's
+// pipeline.
+func createTestApplication(t *testing.T, job models.Job, applicant models.User, coverLetter string) models.Application {
+	t.Helper()
+	pipeline, err := loadJobPipeline(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stage, err := pipeline.FirstStage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	application := models.Application{
+		ApplicantID: applicant.ID,
+		JobID:       job.ID,
+		ResumeLink:  "https://example.com/resume.pdf",
+		CoverLetter: coverLetter,
+		Status:      models.ApplicationStatus(stage.Name),
+		StageID:     &stage.ID,
+	}
+	if err := config.DB.Create(&application).Error; err != nil {
+		t.Fatal(err)
+	}
+	return application
+}
+
+// serveTest runs handler for one request on route, as the user.
+func serveTest(t *testing.T, handler gin.HandlerFunc, method, route, path string, user models.User, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	engine := gin.New()
+	engine.Handle(method, route, func(c *gin.Context) {
+		c.Set("user_id", user.ID)
+		c.Set("user_role", string(user.Role))
+	}, handler)
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// decodeObject decodes the Object of a BaseResponse into v.
+func decodeObject(t *testing.T, recorder *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	var response struct {
+		Object json.RawMessage `json:"object"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decoding response %s: %v", recorder.Body.String(), err)
+	}
+	if err := json.Unmarshal(response.Object, v); err != nil {
+		t.Fatalf("decoding object %s: %v", response.Object, err)
+	}
+}
+
+func expectStatus(t *testing.T, recorder *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if recorder.Code != want {
+		t.Fatalf("status = %d, want %d; body: %s", recorder.Code, want, recorder.Body.String())
+	}
+}
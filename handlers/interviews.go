@@ -0,0 +1,937 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"job-api/config"
+	"job-api/mailer"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InterviewSlotRequest struct {
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+}
+
+type CreateInterviewRequest struct {
+	Title           string                 `json:"title" validate:"required,min=1,max=200"`
+	Description     string                 `json:"description" validate:"max=2000"`
+	DurationMinutes int                    `json:"duration_minutes" validate:"required,min=15,max=480"`
+	Location        string                 `json:"location" validate:"max=300"`
+	VideoLink       string                 `json:"video_link" validate:"omitempty,url"`
+	InterviewerIDs  []uuid.UUID            `json:"interviewer_ids" validate:"required,min=1,max=10"`
+	Slots           []InterviewSlotRequest `json:"slots" validate:"required,min=1,max=10,dive"`
+}
+
+// Setting starts_at schedules a proposed interview or reschedules it.
+type UpdateInterviewRequest struct {
+	Title           string      `json:"title" validate:"required,min=1,max=200"`
+	Description     string      `json:"description" validate:"max=2000"`
+	DurationMinutes int         `json:"duration_minutes" validate:"required,min=15,max=480"`
+	Location        string      `json:"location" validate:"max=300"`
+	VideoLink       string      `json:"video_link" validate:"omitempty,url"`
+	InterviewerIDs  []uuid.UUID `json:"interviewer_ids" validate:"required,min=1,max=10"`
+	StartsAt        *time.Time  `json:"starts_at"`
+}
+
+type SelectInterviewSlotRequest struct {
+	SlotID uuid.UUID `json:"slot_id" validate:"required"`
+}
+
+type CancelInterviewRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+var (
+	errInterviewNotFound = errors.New("interview not found")
+	errInterviewConflict = errors.New("interviewers are not available")
+)
+
+// CreateInterview proposes interview slots to an applicant.
+func CreateInterview(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req CreateInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	application, err := findTeamApplication(appUUID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	if application.Status == models.StatusWithdrawn {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Application has been withdrawn",
+			Object:  nil,
+		})
+		return
+	}
+
+	interviewers, err := loadInterviewers(application.Job.OrganizationID, req.InterviewerIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid interviewers",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	var slots []models.InterviewSlot
+	seen := make(map[time.Time]bool)
+	for _, slotReq := range req.Slots {
+		start := slotReq.StartsAt.UTC()
+		if !start.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Validation failed",
+				Object:  nil,
+				Errors:  []string{"interview slots must be in the future"},
+			})
+			return
+		}
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+
+		slots = append(slots, models.InterviewSlot{StartsAt: start, EndsAt: start.Add(duration)})
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].StartsAt.Before(slots[j].StartsAt) })
+
+	interview := models.Interview{
+		ApplicationID:   application.ID,
+		Title:           strings.TrimSpace(req.Title),
+		Description:     strings.TrimSpace(req.Description),
+		DurationMinutes: req.DurationMinutes,
+		Location:        strings.TrimSpace(req.Location),
+		VideoLink:       req.VideoLink,
+		Status:          models.InterviewProposed,
+		CreatedBy:       currentUserID,
+		Slots:           slots,
+		Interviewers:    interviewers,
+	}
+
+	var conflicts []string
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockInterviewers(tx, userIDs(interviewers)); err != nil {
+			return err
+		}
+		for _, slot := range slots {
+			found, err := findInterviewConflicts(tx, userIDs(interviewers), slot.StartsAt, slot.EndsAt, uuid.Nil)
+			if err != nil {
+				return err
+			}
+			conflicts = append(conflicts, found...)
+		}
+		if len(conflicts) > 0 {
+			return errInterviewConflict
+		}
+
+		// Link the interviewers without re-saving their user records
+		if err := tx.Omit("Interviewers.*").Create(&interview).Error; err != nil {
+			return err
+		}
+		return notifyInterview(tx, interview, application, models.NotificationInterviewProposed,
+			"Interview slots proposed for "+application.Job.Title, []uuid.UUID{application.ApplicantID})
+	})
+	if errors.Is(err, errInterviewConflict) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Interviewers are not available for some slots",
+			Object:  nil,
+			Errors:  conflicts,
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create interview",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Interview proposed successfully",
+		Object:  interview,
+	})
+}
+
+func GetApplicationInterviews(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	userRole, _ := c.Get("user_role")
+
+	var application models.Application
+	if err := config.DB.Preload("Job").First(&application, appUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Application not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	isApplicant := userRole == string(models.RoleApplicant) && application.ApplicantID == currentUserID
+	isTeam := userRole == string(models.RoleCompany) && canManageJob(currentUserID, application.Job)
+	if !isApplicant && !isTeam {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Object:  nil,
+		})
+		return
+	}
+
+	var interviews []models.Interview
+	if err := config.DB.Preload("Slots", func(db *gorm.DB) *gorm.DB {
+		return db.Order("starts_at ASC")
+	}).Preload("Interviewers").
+		Where("application_id = ?", application.ID).
+		Order("created_at ASC").Find(&interviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch interviews",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Interviews retrieved successfully",
+		Object:  interviews,
+	})
+}
+
+func SelectInterviewSlot(c *gin.Context) {
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid interview ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req SelectInterviewSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	applicantID := userID.(uuid.UUID)
+
+	interview, application, err := loadInterview(interviewUUID)
+	if err != nil || application.ApplicantID != applicantID {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Interview not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if interview.Status != models.InterviewProposed {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Interview is not awaiting a slot selection",
+			Object:  nil,
+		})
+		return
+	}
+
+	var slot *models.InterviewSlot
+	for i := range interview.Slots {
+		if interview.Slots[i].ID == req.SlotID {
+			slot = &interview.Slots[i]
+		}
+	}
+	if slot == nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Slot does not belong to this interview",
+			Object:  nil,
+		})
+		return
+	}
+	if !slot.StartsAt.After(time.Now()) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Slot is in the past",
+			Object:  nil,
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		// Interviewers may have been booked since the slots were proposed
+		if err := lockInterviewers(tx, userIDs(interview.Interviewers)); err != nil {
+			return err
+		}
+		conflicts, err := findInterviewConflicts(tx, userIDs(interview.Interviewers), slot.StartsAt, slot.EndsAt, interview.ID)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return errInterviewConflict
+		}
+
+		result := tx.Model(&models.Interview{}).
+			Where("id = ? AND status = ?", interview.ID, models.InterviewProposed).
+			Updates(map[string]interface{}{
+				"status":    models.InterviewScheduled,
+				"starts_at": slot.StartsAt,
+				"ends_at":   slot.EndsAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("interview was changed by another request")
+		}
+
+		interview.Status = models.InterviewScheduled
+		interview.StartsAt = &slot.StartsAt
+		interview.EndsAt = &slot.EndsAt
+		if err := notifyInterview(tx, interview, application, models.NotificationInterviewScheduled,
+			"Interview scheduled with "+applicantDisplayName(application), userIDs(interview.Interviewers)); err != nil {
+			return err
+		}
+		return queueNotificationEmails(tx, interviewEmails(interview, application, models.NotificationInterviewScheduled,
+			append([]uuid.UUID{application.ApplicantID}, userIDs(interview.Interviewers)...))...)
+	})
+	if errors.Is(err, errInterviewConflict) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Slot is no longer available, please choose another",
+			Object:  nil,
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to schedule interview",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Interview scheduled successfully",
+		Object:  interview,
+	})
+}
+
+func UpdateInterview(c *gin.Context) {
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid interview ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req UpdateInterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	interview, application, err := loadInterview(interviewUUID)
+	if err != nil || !canManageJob(currentUserID, application.Job) {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Interview not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if interview.Status == models.InterviewCancelled {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Cancelled interviews cannot be changed",
+			Object:  nil,
+		})
+		return
+	}
+
+	interviewers, err := loadInterviewers(application.Job.OrganizationID, req.InterviewerIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid interviewers",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	wasScheduled := interview.Status == models.InterviewScheduled
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	if req.StartsAt != nil {
+		start := req.StartsAt.UTC()
+		if !start.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Validation failed",
+				Object:  nil,
+				Errors:  []string{"starts_at must be in the future"},
+			})
+			return
+		}
+		end := start.Add(duration)
+		interview.StartsAt = &start
+		interview.EndsAt = &end
+		interview.Status = models.InterviewScheduled
+	} else if interview.StartsAt != nil {
+		end := interview.StartsAt.Add(duration)
+		interview.EndsAt = &end
+	}
+
+	// Proposed slots last as long as the interview
+	durationChanged := req.DurationMinutes != interview.DurationMinutes
+	for i := range interview.Slots {
+		interview.Slots[i].EndsAt = interview.Slots[i].StartsAt.Add(duration)
+	}
+
+	interview.Title = strings.TrimSpace(req.Title)
+	interview.Description = strings.TrimSpace(req.Description)
+	interview.DurationMinutes = req.DurationMinutes
+	interview.Location = strings.TrimSpace(req.Location)
+	interview.VideoLink = req.VideoLink
+	if interview.Status == models.InterviewScheduled {
+		// Calendar clients only replace an invite with a higher sequence
+		interview.Sequence++
+	}
+
+	previousInterviewers := userIDs(interview.Interviewers)
+	interview.Interviewers = interviewers
+
+	notificationType := models.NotificationInterviewUpdated
+	title := "Interview updated for " + application.Job.Title
+	if !wasScheduled && interview.Status == models.InterviewScheduled {
+		notificationType = models.NotificationInterviewScheduled
+		title = "Interview scheduled for " + application.Job.Title
+	}
+	attendees := append([]uuid.UUID{application.ApplicantID}, userIDs(interviewers)...)
+
+	var emails []notificationEmail
+	if interview.Status == models.InterviewScheduled {
+		emails = interviewEmails(interview, application, notificationType, attendees)
+
+		// Interviewers taken off the interview get a cancellation for their calendar
+		current := make(map[uuid.UUID]bool)
+		for _, id := range attendees {
+			current[id] = true
+		}
+		var removed []uuid.UUID
+		for _, id := range previousInterviewers {
+			if !current[id] {
+				removed = append(removed, id)
+			}
+		}
+		withdrawn := interview
+		withdrawn.Status = models.InterviewCancelled
+		emails = append(emails, interviewEmails(withdrawn, application, models.NotificationInterviewCancelled, removed)...)
+	}
+
+	var conflicts []string
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if interview.Status == models.InterviewScheduled {
+			if err := lockInterviewers(tx, userIDs(interviewers)); err != nil {
+				return err
+			}
+			found, err := findInterviewConflicts(tx, userIDs(interviewers), *interview.StartsAt, *interview.EndsAt, interview.ID)
+			if err != nil {
+				return err
+			}
+			if len(found) > 0 {
+				conflicts = found
+				return errInterviewConflict
+			}
+		}
+
+		if err := tx.Omit("Interviewers", "Slots").Save(&interview).Error; err != nil {
+			return err
+		}
+		if durationChanged {
+			for _, slot := range interview.Slots {
+				if err := tx.Model(&slot).Update("ends_at", slot.EndsAt).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.Model(&interview).Omit("Interviewers.*").Association("Interviewers").Replace(interviewers); err != nil {
+			return err
+		}
+		if err := notifyInterview(tx, interview, application, notificationType, title, append(attendees, previousInterviewers...)); err != nil {
+			return err
+		}
+		return queueNotificationEmails(tx, emails...)
+	})
+	if errors.Is(err, errInterviewConflict) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Interviewers are not available at this time",
+			Object:  nil,
+			Errors:  conflicts,
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update interview",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Interview updated successfully",
+		Object:  interview,
+	})
+}
+
+// CancelInterview can be used by the hiring team or the applicant.
+func CancelInterview(c *gin.Context) {
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid interview ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	// The body is optional
+	var req CancelInterviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Invalid request data",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	interview, application, err := loadInterview(interviewUUID)
+	isApplicant := err == nil && application.ApplicantID == currentUserID
+	isTeam := err == nil && canManageJob(currentUserID, application.Job)
+	if !isApplicant && !isTeam {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Interview not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if interview.Status == models.InterviewCancelled {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Interview is already cancelled",
+			Object:  nil,
+		})
+		return
+	}
+
+	interview.Status = models.InterviewCancelled
+	interview.CancelReason = strings.TrimSpace(req.Reason)
+	interview.Sequence++
+	interview.UpdatedAt = time.Now()
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Interview{}).Where("id = ?", interview.ID).
+			Updates(map[string]interface{}{
+				"status":        interview.Status,
+				"cancel_reason": interview.CancelReason,
+				"sequence":      interview.Sequence,
+				"updated_at":    interview.UpdatedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		recipients := userIDs(interview.Interviewers)
+		if isTeam {
+			recipients = append(recipients, application.ApplicantID)
+		} else {
+			// The job owner hears about it even when not interviewing
+			recipients = append(recipients, application.Job.CreatedBy)
+		}
+		if err := notifyInterview(tx, interview, application, models.NotificationInterviewCancelled,
+			"Interview cancelled for "+application.Job.Title, recipients); err != nil {
+			return err
+		}
+		return queueNotificationEmails(tx, interviewEmails(interview, application, models.NotificationInterviewCancelled,
+			append([]uuid.UUID{application.ApplicantID}, userIDs(interview.Interviewers)...))...)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to cancel interview",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Interview cancelled successfully",
+		Object:  interview,
+	})
+}
+
+// Cancelled interviews get the cancellation instead of the invite.
+func GetInterviewInvite(c *gin.Context) {
+	interviewUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid interview ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	interview, application, err := loadInterview(interviewUUID)
+	if err != nil || (application.ApplicantID != currentUserID && !canManageJob(currentUserID, application.Job)) {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Interview not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if interview.StartsAt == nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Interview has not been scheduled yet",
+			Object:  nil,
+		})
+		return
+	}
+
+	invite := interviewInvite(interview, application, application.ApplicantID != currentUserID)
+	c.Header("Content-Disposition", `attachment; filename="interview.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8; method="+string(invite.Method), invite.Encode())
+}
+
+// The hiring team's copy leaves out an applicant hidden by blind review.
+func interviewInvite(interview models.Interview, application models.Application, forTeam bool) utils.ICalEvent {
+	method := utils.ICalRequest
+	if interview.Status == models.InterviewCancelled {
+		method = utils.ICalCancel
+	}
+
+	description := interview.Description
+	if interview.VideoLink != "" {
+		description = strings.TrimSpace(description + "\n\nJoin: " + interview.VideoLink)
+	}
+	location := interview.Location
+	if location == "" {
+		location = interview.VideoLink
+	}
+
+	event := utils.ICalEvent{
+		UID:         interview.ID.String() + "@job-api",
+		Sequence:    interview.Sequence,
+		Method:      method,
+		Summary:     interview.Title + " - " + application.Job.Title,
+		Description: description,
+		Location:    location,
+		URL:         interview.VideoLink,
+		Organizer:   utils.ICalAttendee{Name: application.Job.Creator.Name, Email: application.Job.Creator.Email},
+		Stamp:       interview.UpdatedAt,
+	}
+	if !forTeam || !applicationHidden(application) {
+		event.Attendees = append(event.Attendees, utils.ICalAttendee{Name: application.Applicant.Name, Email: application.Applicant.Email})
+	}
+	if interview.StartsAt != nil && interview.EndsAt != nil {
+		event.Start = *interview.StartsAt
+		event.End = *interview.EndsAt
+	}
+	for _, interviewer := range interview.Interviewers {
+		event.Attendees = append(event.Attendees, utils.ICalAttendee{Name: interviewer.Name, Email: interviewer.Email})
+	}
+	return event
+}
+
+func interviewEmails(interview models.Interview, application models.Application, notificationType models.NotificationType, recipients []uuid.UUID) []notificationEmail {
+	data := map[string]string{
+		"interview_title": interview.Title,
+		"job_title":       application.Job.Title,
+		"company_name":    application.Job.Creator.Name,
+		"location":        interview.Location,
+		"video_link":      interview.VideoLink,
+		"reason":          interview.CancelReason,
+	}
+
+	var attachments, teamAttachments []mailer.Attachment
+	if interview.StartsAt != nil {
+		data["starts_at"] = formatEmailTime(*interview.StartsAt)
+		for _, forTeam := range []bool{false, true} {
+			invite := interviewInvite(interview, application, forTeam)
+			attachment := mailer.Attachment{
+				FileName:    "interview.ics",
+				ContentType: "text/calendar; charset=utf-8; method=" + string(invite.Method),
+				Data:        invite.Encode(),
+			}
+			if forTeam {
+				teamAttachments = append(teamAttachments, attachment)
+			} else {
+				attachments = append(attachments, attachment)
+			}
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var emails []notificationEmail
+	for _, recipient := range recipients {
+		if seen[recipient] {
+			continue
+		}
+		seen[recipient] = true
+		email := notificationEmail{
+			UserID:      recipient,
+			Type:        notificationType,
+			Data:        data,
+			Attachments: attachments,
+		}
+		if recipient != application.ApplicantID {
+			email.Attachments = teamAttachments
+		}
+		emails = append(emails, email)
+	}
+	return emails
+}
+
+func loadInterview(interviewID uuid.UUID) (models.Interview, models.Application, error) {
+	var interview models.Interview
+	var application models.Application
+	if err := config.DB.Preload("Slots", func(db *gorm.DB) *gorm.DB {
+		return db.Order("starts_at ASC")
+	}).Preload("Interviewers").First(&interview, interviewID).Error; err != nil {
+		return interview, application, errInterviewNotFound
+	}
+	if err := config.DB.Preload("Applicant").Preload("Job.Creator").
+		First(&application, interview.ApplicationID).Error; err != nil {
+		return interview, application, errInterviewNotFound
+	}
+	return interview, application, nil
+}
+
+func loadInterviewers(orgID uuid.UUID, ids []uuid.UUID) ([]models.User, error) {
+	team, err := organizationUsers(orgID)
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[uuid.UUID]models.User, len(team))
+	for _, user := range team {
+		members[user.ID] = user
+	}
+
+	var interviewers []models.User
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range ids {
+		user, ok := members[id]
+		if !ok {
+			return nil, fmt.Errorf("user %s is not a member of this organization", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			interviewers = append(interviewers, user)
+		}
+	}
+	return interviewers, nil
+}
+
+// Locking the interviewers' rows makes transactions booking the same people
+// run one at a time and see each other's interviews.
+func lockInterviewers(tx *gorm.DB, users []uuid.UUID) error {
+	var locked []uuid.UUID
+	return tx.Model(&models.User{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", users).Order("id").Pluck("id", &locked).Error
+}
+
+// Callers lock the users first with lockInterviewers.
+func findInterviewConflicts(tx *gorm.DB, users []uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]string, error) {
+	var interviews []models.Interview
+	err := tx.Preload("Interviewers").
+		Where("status = ? AND id <> ? AND starts_at < ? AND ends_at > ?", models.InterviewScheduled, excludeID, end, start).
+		Where("id IN (?)", tx.Table("interview_interviewers").Select("interview_id").Where("user_id IN ?", users)).
+		Find(&interviews).Error
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make(map[uuid.UUID]bool, len(users))
+	for _, id := range users {
+		busy[id] = true
+	}
+
+	var conflicts []string
+	for _, interview := range interviews {
+		for _, interviewer := range interview.Interviewers {
+			if busy[interviewer.ID] {
+				conflicts = append(conflicts, fmt.Sprintf("%s has another interview from %s to %s",
+					interviewer.Name, interview.StartsAt.Format(time.RFC3339), interview.EndsAt.Format(time.RFC3339)))
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+func notifyInterview(tx *gorm.DB, interview models.Interview, application models.Application, notificationType models.NotificationType, title string, recipients []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool)
+	var notifications []models.Notification
+	for _, recipient := range recipients {
+		if seen[recipient] {
+			continue
+		}
+		seen[recipient] = true
+
+		body := interview.Title
+		if interview.StartsAt != nil {
+			body += " on " + interview.StartsAt.UTC().Format("Mon, 02 Jan 2006 15:04 MST")
+		}
+		notifications = append(notifications, models.Notification{
+			UserID: recipient,
+			Type:   notificationType,
+			Title:  title,
+			Body:   body,
+			Data: map[string]string{
+				"application_id": application.ID.String(),
+				"interview_id":   interview.ID.String(),
+			},
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+	return createNotifications(tx, notifications)
+}
@@ -1,27 +1,69 @@
 package handlers
 
 import (
+	"errors"
 	"job-api/config"
 	"job-api/models"
 	"job-api/utils"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type CreateJobRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=100"`
-	Description string `json:"description" validate:"required,min=20,max=2000"`
-	Location    string `json:"location"`
+	Title          string                     `json:"title" validate:"required,min=1,max=100"`
+	Description    string                     `json:"description" validate:"required,min=20,max=2000"`
+	Location       string                     `json:"location"`
+	Skills         []string                   `json:"skills" validate:"max=30,dive,min=1,max=50"`
+	Status         models.JobStatus           `json:"status" validate:"omitempty,oneof=Draft Open"`
+	EmploymentType string                     `json:"employment_type" validate:"omitempty,oneof=FULL_TIME PART_TIME CONTRACTOR TEMPORARY INTERN VOLUNTEER PER_DIEM OTHER"`
+	SalaryMin      *float64                   `json:"salary_min" validate:"omitempty,gte=0"`
+	SalaryMax      *float64                   `json:"salary_max" validate:"omitempty,gte=0"`
+	SalaryCurrency string                     `json:"salary_currency" validate:"omitempty,len=3,uppercase"`
+	SalaryPeriod   string                     `json:"salary_period" validate:"omitempty,oneof=HOUR DAY WEEK MONTH YEAR"`
+	ValidThrough   *time.Time                 `json:"valid_through"`
+	PipelineID     *uuid.UUID                 `json:"pipeline_id"`
+	Questions      []ScreeningQuestionRequest `json:"screening_questions" validate:"max=20,dive"`
+	// BlindReview hides applicants until they pass BlindRevealStageID
+	BlindReview        bool       `json:"blind_review"`
+	BlindRevealStageID *uuid.UUID `json:"blind_reveal_stage_id"`
+	Headcount          *int       `json:"headcount" validate:"omitempty,min=1"`
+	CloseWhenFilled    bool       `json:"close_when_filled"`
 }
 
 type UpdateJobRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=100"`
-	Description string `json:"description" validate:"required,min=20,max=2000"`
-	Location    string `json:"location"`
+	Title          string           `json:"title" validate:"required,min=1,max=100"`
+	Description    string           `json:"description" validate:"required,min=20,max=2000"`
+	Location       string           `json:"location"`
+	Skills         []string         `json:"skills" validate:"max=30,dive,min=1,max=50"`
+	Status         models.JobStatus `json:"status" validate:"omitempty,oneof=Draft Open Closed"`
+	EmploymentType string           `json:"employment_type" validate:"omitempty,oneof=FULL_TIME PART_TIME CONTRACTOR TEMPORARY INTERN VOLUNTEER PER_DIEM OTHER"`
+	SalaryMin      *float64         `json:"salary_min" validate:"omitempty,gte=0"`
+	SalaryMax      *float64         `json:"salary_max" validate:"omitempty,gte=0"`
+	SalaryCurrency string           `json:"salary_currency" validate:"omitempty,len=3,uppercase"`
+	SalaryPeriod   string           `json:"salary_period" validate:"omitempty,oneof=HOUR DAY WEEK MONTH YEAR"`
+	ValidThrough   *time.Time       `json:"valid_through"`
+	PipelineID     *uuid.UUID       `json:"pipeline_id"`
+	// Questions replaces the job's screening questions when present
+	Questions *[]ScreeningQuestionRequest `json:"screening_questions" validate:"omitempty,max=20,dive"`
+	// BlindReview and BlindRevealStageID are left unchanged when absent
+	BlindReview        *bool      `json:"blind_review"`
+	BlindRevealStageID *uuid.UUID `json:"blind_reveal_stage_id"`
+	// Left unchanged when absent; a headcount of 0 clears it
+	Headcount       *int  `json:"headcount" validate:"omitempty,min=0"`
+	CloseWhenFilled *bool `json:"close_when_filled"`
+}
+
+func validateSalaryRange(min, max *float64) error {
+	if min != nil && max != nil && *max < *min {
+		return errors.New("salary_max must be greater than or equal to salary_min")
+	}
+	return nil
 }
 
 func CreateJob(c *gin.Context) {
@@ -46,15 +88,74 @@ func CreateJob(c *gin.Context) {
 		return
 	}
 
+	if err := validateSalaryRange(req.SalaryMin, req.SalaryMax); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
 	userID, _ := c.Get("user_id")
 	createdBy := userID.(uuid.UUID)
 
+	pipeline, err := resolveJobPipeline(req.PipelineID, createdBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid pipeline",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := validateBlindReview(pipeline, req.BlindReview, req.BlindRevealStageID); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	questions, err := buildScreeningQuestions(uuid.Nil, req.Questions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
 	job := models.Job{
-		Title:       req.Title,
-		Description: req.Description,
-		Location:    req.Location,
-		CreatedBy:   createdBy,
+		Title:          req.Title,
+		Description:    req.Description,
+		Location:       req.Location,
+		Skills:         utils.NormalizeSkills(req.Skills),
+		Status:         req.Status,
+		EmploymentType: req.EmploymentType,
+		SalaryMin:      req.SalaryMin,
+		SalaryMax:      req.SalaryMax,
+		SalaryCurrency: req.SalaryCurrency,
+		SalaryPeriod:   req.SalaryPeriod,
+		ValidThrough:   req.ValidThrough,
+		PipelineID:     &pipeline.ID,
+		CreatedBy:      createdBy,
+		OrganizationID: organizationID(createdBy),
+		Questions:      questions,
+	}
+	if req.BlindReview {
+		job.BlindReview = true
+		job.BlindRevealStageID = req.BlindRevealStageID
 	}
+	job.Headcount = req.Headcount
+	job.CloseWhenFilled = req.CloseWhenFilled
 
 	if err := config.DB.Create(&job).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, models.BaseResponse{
@@ -109,6 +210,16 @@ func UpdateJob(c *gin.Context) {
 		return
 	}
 
+	if err := validateSalaryRange(req.SalaryMin, req.SalaryMax); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
 	userID, _ := c.Get("user_id")
 	currentUserID := userID.(uuid.UUID)
 
@@ -122,7 +233,7 @@ func UpdateJob(c *gin.Context) {
 		return
 	}
 
-	if job.CreatedBy != currentUserID {
+	if !canManageJob(currentUserID, job) {
 		c.JSON(http.StatusForbidden, models.BaseResponse{
 			Success: false,
 			Message: "Unauthorized access",
@@ -131,11 +242,136 @@ func UpdateJob(c *gin.Context) {
 		return
 	}
 
+	if req.PipelineID != nil && (job.PipelineID == nil || *job.PipelineID != *req.PipelineID) {
+		var applicationCount int64
+		config.DB.Model(&models.Application{}).Where("job_id = ?", job.ID).Count(&applicationCount)
+		if applicationCount > 0 {
+			c.JSON(http.StatusConflict, models.BaseResponse{
+				Success: false,
+				Message: "Pipeline cannot be changed once the job has applications",
+				Object:  nil,
+			})
+			return
+		}
+
+		pipeline, err := resolveJobPipeline(req.PipelineID, currentUserID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Invalid pipeline",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+		job.PipelineID = &pipeline.ID
+	}
+
+	var questions []models.ScreeningQuestion
+	if req.Questions != nil {
+		var applicationCount int64
+		config.DB.Model(&models.Application{}).Where("job_id = ?", job.ID).Count(&applicationCount)
+		if applicationCount > 0 {
+			c.JSON(http.StatusConflict, models.BaseResponse{
+				Success: false,
+				Message: "Screening questions cannot be changed once the job has applications",
+				Object:  nil,
+			})
+			return
+		}
+
+		questions, err = buildScreeningQuestions(job.ID, *req.Questions)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Validation failed",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	wasBlind := job.BlindReview
+	if req.BlindReview != nil {
+		job.BlindReview = *req.BlindReview
+	}
+	if req.BlindRevealStageID != nil {
+		job.BlindRevealStageID = req.BlindRevealStageID
+	}
+	if job.BlindReview {
+		pipeline, err := loadJobPipeline(job)
+		if err == nil {
+			err = validateBlindReview(pipeline, job.BlindReview, job.BlindRevealStageID)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Validation failed",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
 	job.Title = req.Title
 	job.Description = req.Description
 	job.Location = req.Location
+	job.Skills = utils.NormalizeSkills(req.Skills)
+	job.EmploymentType = req.EmploymentType
+	job.SalaryMin = req.SalaryMin
+	job.SalaryMax = req.SalaryMax
+	job.SalaryCurrency = req.SalaryCurrency
+	job.SalaryPeriod = req.SalaryPeriod
+	if (job.ValidThrough == nil) != (req.ValidThrough == nil) ||
+		(req.ValidThrough != nil && !job.ValidThrough.Equal(*req.ValidThrough)) {
+		// A new closing date gets its own reminder
+		job.ClosingReminderSentAt = nil
+	}
+	job.ValidThrough = req.ValidThrough
+	if req.Status != "" {
+		job.Status = req.Status
+	}
+	if req.Headcount != nil {
+		job.Headcount = req.Headcount
+		if *req.Headcount == 0 {
+			job.Headcount = nil
+		}
+	}
+	if req.CloseWhenFilled != nil {
+		job.CloseWhenFilled = *req.CloseWhenFilled
+	}
 
-	if err := config.DB.Save(&job).Error; err != nil {
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&job).Error; err != nil {
+			return err
+		}
+		if wasBlind && !job.BlindReview {
+			// Turning blind review off reveals everyone still hidden
+			var ids []uuid.UUID
+			if err := tx.Model(&models.Application{}).Where("job_id = ? AND revealed_at IS NULL", job.ID).
+				Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			if len(ids) > 0 {
+				if err := revealApplications(tx, ids, &currentUserID, false, "Blind review turned off"); err != nil {
+					return err
+				}
+			}
+		}
+		if req.Questions == nil {
+			return nil
+		}
+		if err := tx.Where("job_id = ?", job.ID).Delete(&models.ScreeningQuestion{}).Error; err != nil {
+			return err
+		}
+		if len(questions) == 0 {
+			return nil
+		}
+		return tx.Create(&questions).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.BaseResponse{
 			Success: false,
 			Message: "Failed to update job",
@@ -145,6 +381,8 @@ func UpdateJob(c *gin.Context) {
 		return
 	}
 
+	config.DB.Where("job_id = ?", job.ID).Order("position ASC").Find(&job.Questions)
+
 	c.JSON(http.StatusOK, models.BaseResponse{
 		Success: true,
 		Message: "Job updated successfully",
@@ -177,7 +415,7 @@ func DeleteJob(c *gin.Context) {
 		return
 	}
 
-	if job.CreatedBy != currentUserID {
+	if !canManageJob(currentUserID, job) {
 		c.JSON(http.StatusForbidden, models.BaseResponse{
 			Success: false,
 			Message: "Unauthorized access",
@@ -186,7 +424,12 @@ func DeleteJob(c *gin.Context) {
 		return
 	}
 
-	if err := config.DB.Delete(&job).Error; err != nil {
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&job).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.DeletedJob{JobID: job.ID, DeletedAt: time.Now()}).Error
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, models.BaseResponse{
 			Success: false,
 			Message: "Failed to delete job",
@@ -203,13 +446,30 @@ func DeleteJob(c *gin.Context) {
 	})
 }
 
-func BrowseJobs(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+func filterJobs(c *gin.Context) func(*gorm.DB) *gorm.DB {
 	title := c.Query("title")
 	location := c.Query("location")
 	companyName := c.Query("company_name")
 
+	return func(query *gorm.DB) *gorm.DB {
+		if title != "" {
+			query = query.Where("LOWER(jobs.title) LIKE ?", "%"+strings.ToLower(title)+"%")
+		}
+		if location != "" {
+			query = query.Where("LOWER(jobs.location) LIKE ?", "%"+strings.ToLower(location)+"%")
+		}
+		if companyName != "" {
+			query = query.Where("jobs.created_by IN (SELECT id FROM users WHERE LOWER(users.name) LIKE ?)",
+				"%"+strings.ToLower(companyName)+"%")
+		}
+		return query
+	}
+}
+
+func BrowseJobs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
 	if page < 1 {
 		page = 1
 	}
@@ -219,18 +479,9 @@ func BrowseJobs(c *gin.Context) {
 
 	offset := (page - 1) * pageSize
 
-	query := config.DB.Model(&models.Job{}).Preload("Creator")
-
-	if title != "" {
-		query = query.Where("LOWER(title) LIKE ?", "%"+strings.ToLower(title)+"%")
-	}
-	if location != "" {
-		query = query.Where("LOWER(location) LIKE ?", "%"+strings.ToLower(location)+"%")
-	}
-	if companyName != "" {
-		query = query.Joins("JOIN users ON jobs.created_by = users.id").
-			Where("LOWER(users.name) LIKE ?", "%"+strings.ToLower(companyName)+"%")
-	}
+	query := config.DB.Model(&models.Job{}).Preload("Creator").
+		Where("jobs.status <> ?", models.JobStatusDraft).
+		Scopes(filterJobs(c))
 
 	var total int64
 	query.Count(&total)
@@ -268,8 +519,18 @@ func GetJobDetails(c *gin.Context) {
 		return
 	}
 
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
 	var job models.Job
-	if err := config.DB.Preload("Creator").First(&job, jobUUID).Error; err != nil {
+	if err := config.DB.Preload("Creator").
+		Preload("Pipeline.Stages", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		First(&job, jobUUID).Error; err != nil {
 		c.JSON(http.StatusNotFound, models.BaseResponse{
 			Success: false,
 			Message: "Job not found",
@@ -278,6 +539,21 @@ func GetJobDetails(c *gin.Context) {
 		return
 	}
 
+	// Drafts are only visible to the company that owns them
+	isTeam := canManageJob(currentUserID, job)
+	if job.Status == models.JobStatusDraft && !isTeam {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Job not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if !isTeam {
+		hideKnockoutRules(job.Questions)
+	}
+
 	c.JSON(http.StatusOK, models.BaseResponse{
 		Success: true,
 		Message: "Job details retrieved successfully",
@@ -0,0 +1,667 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SendMessageRequest struct {
+	Body          string      `json:"body" validate:"max=5000"`
+	TemplateID    *uuid.UUID  `json:"template_id"`
+	AttachmentIDs []uuid.UUID `json:"attachment_ids" validate:"max=5"`
+}
+
+type MessageTemplateRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+	Body string `json:"body" validate:"required,min=1,max=5000"`
+}
+
+type ThreadUnreadCount struct {
+	ApplicationID uuid.UUID `json:"application_id"`
+	Unread        int64     `json:"unread"`
+}
+
+type UnreadMessagesResponse struct {
+	Total   int64               `json:"total"`
+	Threads []ThreadUnreadCount `json:"threads"`
+}
+
+var errMessageTemplateNotFound = errors.New("message template not found")
+
+func GetApplicationMessages(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+
+	application, isTeam, err := findThreadApplication(appUUID, userID.(uuid.UUID), userRole)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var total int64
+	config.DB.Model(&models.Message{}).Where("application_id = ?", application.ID).Count(&total)
+
+	var messages []models.Message
+	if err := config.DB.Preload("Sender").Preload("Attachments").
+		Where("application_id = ?", application.ID).
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).Find(&messages).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch messages",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if isTeam && applicationHidden(application) {
+		redactMessages(messages, application)
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Success:    true,
+		Message:    "Messages retrieved successfully",
+		Object:     messages,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  total,
+	})
+}
+
+func SendMessage(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	senderID := userID.(uuid.UUID)
+	userRole, _ := c.Get("user_role")
+
+	application, isTeam, err := findThreadApplication(appUUID, senderID, userRole)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if req.TemplateID != nil {
+		if !isTeam {
+			c.JSON(http.StatusForbidden, models.BaseResponse{
+				Success: false,
+				Message: "Only the hiring team can use message templates",
+				Object:  nil,
+			})
+			return
+		}
+
+		template, err := findMessageTemplate(*req.TemplateID, application.Job.OrganizationID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Message template not found",
+				Object:  nil,
+			})
+			return
+		}
+		if body == "" {
+			body, err = renderMessageTemplate(template, application, senderID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, models.BaseResponse{
+					Success: false,
+					Message: "Failed to render message template",
+					Object:  nil,
+					Errors:  []string{err.Error()},
+				})
+				return
+			}
+		}
+	}
+
+	if body == "" {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{"a message needs a body or a template"},
+		})
+		return
+	}
+
+	attachments, err := loadMessageAttachments(senderID, req.AttachmentIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid attachments",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	message := models.Message{
+		ApplicationID: application.ID,
+		SenderID:      senderID,
+		Body:          body,
+		TemplateID:    req.TemplateID,
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		return recordMessage(tx, &message, application, isTeam, attachments)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to send message",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	config.DB.Preload("Sender").Preload("Attachments").First(&message, message.ID)
+	if isTeam && applicationHidden(application) {
+		messages := []models.Message{message}
+		redactMessages(messages, application)
+		message = messages[0]
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Message sent successfully",
+		Object:  message,
+	})
+}
+
+func MarkMessagesRead(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	userRole, _ := c.Get("user_role")
+
+	application, isTeam, err := findThreadApplication(appUUID, currentUserID, userRole)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		return markThreadRead(tx, application, currentUserID, isTeam, time.Now())
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to mark messages as read",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Messages marked as read",
+		Object:  nil,
+	})
+}
+
+func GetUnreadMessageCounts(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	userRole, _ := c.Get("user_role")
+
+	query := config.DB.Table("messages").
+		Select("messages.application_id, COUNT(*) AS unread").
+		Joins("JOIN applications ON applications.id = messages.application_id").
+		Joins("LEFT JOIN message_thread_reads ON message_thread_reads.application_id = messages.application_id AND message_thread_reads.user_id = ?", currentUserID).
+		Where("messages.sender_id <> ?", currentUserID).
+		Where("message_thread_reads.last_read_at IS NULL OR messages.created_at > message_thread_reads.last_read_at")
+	if userRole == string(models.RoleCompany) {
+		query = query.Where("applications.job_id IN (?)", organizationJobIDs(organizationID(currentUserID)))
+	} else {
+		query = query.Where("applications.applicant_id = ?", currentUserID)
+	}
+
+	response := UnreadMessagesResponse{Threads: []ThreadUnreadCount{}}
+	if err := query.Group("messages.application_id").Order("messages.application_id").
+		Scan(&response.Threads).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to count unread messages",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	for _, thread := range response.Threads {
+		response.Total += thread.Unread
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Unread message counts retrieved successfully",
+		Object:  response,
+	})
+}
+
+func GetMessageTemplates(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	orgID := organizationID(userID.(uuid.UUID))
+
+	var templates []models.MessageTemplate
+	if err := config.DB.Where("organization_id = ?", orgID).Order("name ASC").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch message templates",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Message templates retrieved successfully",
+		Object:  templates,
+	})
+}
+
+func CreateMessageTemplate(c *gin.Context) {
+	var req MessageTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	template := models.MessageTemplate{
+		OrganizationID: organizationID(currentUserID),
+		Name:           strings.TrimSpace(req.Name),
+		Body:           strings.TrimSpace(req.Body),
+		CreatedBy:      currentUserID,
+	}
+
+	if err := config.DB.Create(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create message template",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Message template created successfully",
+		Object:  template,
+	})
+}
+
+func UpdateMessageTemplate(c *gin.Context) {
+	templateUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid template ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req MessageTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	template, err := findMessageTemplate(templateUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Message template not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	template.Name = strings.TrimSpace(req.Name)
+	template.Body = strings.TrimSpace(req.Body)
+	if err := config.DB.Save(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update message template",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Message template updated successfully",
+		Object:  template,
+	})
+}
+
+func DeleteMessageTemplate(c *gin.Context) {
+	templateUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid template ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	template, err := findMessageTemplate(templateUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Message template not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if err := config.DB.Delete(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete message template",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Message template deleted successfully",
+		Object:  nil,
+	})
+}
+
+// recordMessage needs the application's Job and Applicant loaded.
+func recordMessage(tx *gorm.DB, message *models.Message, application models.Application, isTeam bool, attachments []models.File) error {
+	if err := tx.Create(message).Error; err != nil {
+		return err
+	}
+
+	if len(attachments) > 0 {
+		// Only claim files that are still unattached
+		result := tx.Model(&models.File{}).
+			Where("id IN ? AND application_id IS NULL", fileIDs(attachments)).
+			Updates(map[string]interface{}{"application_id": application.ID, "message_id": message.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(attachments)) {
+			return errors.New("attachments were used by another request")
+		}
+	}
+
+	// Sending a message means the sender has seen the thread
+	if err := markThreadRead(tx, application, message.SenderID, isTeam, message.CreatedAt); err != nil {
+		return err
+	}
+
+	recipients, err := messageRecipients(tx, application, message.SenderID, isTeam)
+	if err != nil {
+		return err
+	}
+	// A hidden applicant's messages reach the team redacted
+	notified := *message
+	data := map[string]interface{}{"message_id": message.ID, "sender_id": message.SenderID}
+	if !isTeam && applicationHidden(application) {
+		messages := []models.Message{notified}
+		redactMessages(messages, application)
+		notified = messages[0]
+		data["sender_id"] = nil
+	}
+	if err := notifyMessage(tx, notified, application, recipients); err != nil {
+		return err
+	}
+	return publishApplicationEvent(tx, EventMessageCreated, application, application.Job, data)
+}
+
+// findThreadApplication also reports whether the user is on the hiring team.
+func findThreadApplication(applicationID, userID uuid.UUID, userRole interface{}) (models.Application, bool, error) {
+	var application models.Application
+	if err := config.DB.Preload("Applicant").Preload("Job.Creator").First(&application, applicationID).Error; err != nil {
+		return application, false, errApplicationNotFound
+	}
+
+	if userRole == string(models.RoleApplicant) {
+		if application.ApplicantID != userID {
+			return application, false, errApplicationNotFound
+		}
+		return application, false, nil
+	}
+	if !canManageJob(userID, application.Job) {
+		return application, false, errNotHiringTeam
+	}
+	return application, true, nil
+}
+
+func findMessageTemplate(templateID, orgID uuid.UUID) (models.MessageTemplate, error) {
+	var template models.MessageTemplate
+	if err := config.DB.Where("id = ? AND organization_id = ?", templateID, orgID).First(&template).Error; err != nil {
+		return template, errMessageTemplateNotFound
+	}
+	return template, nil
+}
+
+func renderMessageTemplate(template models.MessageTemplate, application models.Application, senderID uuid.UUID) (string, error) {
+	var sender models.User
+	if err := config.DB.First(&sender, senderID).Error; err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(template.Render(map[string]string{
+		"applicant_name": application.Applicant.Name,
+		"job_title":      application.Job.Title,
+		"company_name":   application.Job.Creator.Name,
+		"sender_name":    sender.Name,
+	})), nil
+}
+
+func loadMessageAttachments(senderID uuid.UUID, ids []uuid.UUID) ([]models.File, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var files []models.File
+	if err := config.DB.Where("id IN ?", ids).Find(&files).Error; err != nil {
+		return nil, err
+	}
+	if len(files) != len(ids) {
+		return nil, errors.New("one or more files were not found")
+	}
+	for _, file := range files {
+		if file.OwnerID != senderID {
+			return nil, fmt.Errorf("file %s does not belong to you", file.ID)
+		}
+		if file.Kind != models.FileKindAttachment {
+			return nil, fmt.Errorf("file %s is not an attachment", file.ID)
+		}
+		if file.ApplicationID != nil {
+			return nil, fmt.Errorf("file %s is already attached", file.ID)
+		}
+	}
+	return files, nil
+}
+
+func markThreadRead(tx *gorm.DB, application models.Application, userID uuid.UUID, isTeam bool, readAt time.Time) error {
+	read := models.MessageThreadRead{ApplicationID: application.ID, UserID: userID, LastReadAt: readAt}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "application_id"}, {Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"last_read_at": gorm.Expr("GREATEST(message_thread_reads.last_read_at, EXCLUDED.last_read_at)")}),
+	}).Create(&read).Error; err != nil {
+		return err
+	}
+
+	receipts := tx.Model(&models.Message{}).
+		Where("application_id = ? AND read_at IS NULL AND created_at <= ?", application.ID, readAt)
+	if isTeam {
+		receipts = receipts.Where("sender_id = ?", application.ApplicantID)
+	} else {
+		receipts = receipts.Where("sender_id <> ?", application.ApplicantID)
+	}
+	return receipts.Update("read_at", readAt).Error
+}
+
+// Applicant messages go to the job's organization and to team members who
+// have taken part in the thread.
+func messageRecipients(tx *gorm.DB, application models.Application, senderID uuid.UUID, isTeam bool) ([]uuid.UUID, error) {
+	if isTeam {
+		return []uuid.UUID{application.ApplicantID}, nil
+	}
+
+	var participants []uuid.UUID
+	if err := tx.Model(&models.Message{}).
+		Where("application_id = ? AND sender_id <> ?", application.ID, senderID).
+		Distinct().Pluck("sender_id", &participants).Error; err != nil {
+		return nil, err
+	}
+
+	recipients := []uuid.UUID{application.Job.CreatedBy}
+	for _, id := range participants {
+		if id != application.Job.CreatedBy {
+			recipients = append(recipients, id)
+		}
+	}
+	return recipients, nil
+}
+
+func notifyMessage(tx *gorm.DB, message models.Message, application models.Application, recipients []uuid.UUID) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	notifications := make([]models.Notification, len(recipients))
+	for i, recipient := range recipients {
+		notifications[i] = models.Notification{
+			UserID: recipient,
+			Type:   models.NotificationMessage,
+			Title:  "New message about " + application.Job.Title,
+			Body:   message.Body,
+			Data: map[string]string{
+				"application_id": application.ID.String(),
+				"message_id":     message.ID.String(),
+			},
+		}
+	}
+	return createNotifications(tx, notifications)
+}
+
+func fileIDs(files []models.File) []uuid.UUID {
+	ids := make([]uuid.UUID, len(files))
+	for i, file := range files {
+		ids[i] = file.ID
+	}
+	return ids
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"job-api/queue"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics are off while METRICS_TOKEN is unset.
+func GetMetrics(c *gin.Context) {
+	token := os.Getenv("METRICS_TOKEN")
+	if token == "" {
+		c.String(http.StatusNotFound, "metrics are disabled\n")
+		return
+	}
+	given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+		c.String(http.StatusUnauthorized, "unauthorized\n")
+		return
+	}
+
+	depths, err := queue.Stats(config.DB)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "failed to read queue stats: %v\n", err)
+		return
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	b.WriteString("# HELP job_queue_tasks Background tasks that are pending, running or dead-lettered.\n")
+	b.WriteString("# TYPE job_queue_tasks gauge\n")
+	for _, depth := range depths {
+		fmt.Fprintf(&b, "job_queue_tasks{kind=%q,status=%q} %d\n", depth.Kind, depth.Status, depth.Count)
+	}
+	b.WriteString("# HELP job_queue_oldest_pending_seconds How long the oldest due task has been waiting.\n")
+	b.WriteString("# TYPE job_queue_oldest_pending_seconds gauge\n")
+	for _, depth := range depths {
+		if depth.Status != models.QueueTaskPending || depth.OldestRunAt == nil {
+			continue
+		}
+		age := now.Sub(*depth.OldestRunAt).Seconds()
+		if age < 0 {
+			age = 0
+		}
+		fmt.Fprintf(&b, "job_queue_oldest_pending_seconds{kind=%q} %.0f\n", depth.Kind, age)
+	}
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
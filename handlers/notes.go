@@ -0,0 +1,520 @@
+package handlers
+
+import (
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApplicationNoteRequest struct {
+	Body string `json:"body" validate:"required,min=1,max=5000"`
+}
+
+type ApplicationTimelineEntry struct {
+	Type        string                         `json:"type"`
+	At          time.Time                      `json:"at"`
+	StatusEvent *models.ApplicationStatusEvent `json:"status_event,omitempty"`
+	Note        *models.ApplicationNote        `json:"note,omitempty"`
+}
+
+type ApplicationDetail struct {
+	Application models.Application         `json:"application"`
+	Timeline    []ApplicationTimelineEntry `json:"timeline"`
+}
+
+// Mentions are written as @name or @email of an organization member
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+\-])@([A-Za-z0-9._%+\-]+(?:@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})?)`)
+
+func GetApplication(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	userRole, _ := c.Get("user_role")
+
+	var application models.Application
+	if err := config.DB.Preload("Applicant").Preload("Job").Preload("Stage").
+		First(&application, appUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Application not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	isApplicant := userRole == string(models.RoleApplicant) && application.ApplicantID == currentUserID
+	isTeam := userRole == string(models.RoleCompany) && canManageJob(currentUserID, application.Job)
+	if !isApplicant && !isTeam {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Object:  nil,
+		})
+		return
+	}
+
+	var events []models.ApplicationStatusEvent
+	config.DB.Where("application_id = ?", application.ID).Order("created_at ASC").Find(&events)
+
+	var timeline []ApplicationTimelineEntry
+	for i := range events {
+		if isApplicant {
+			events[i].Reason = ""
+		}
+		timeline = append(timeline, ApplicationTimelineEntry{Type: "status_change", At: events[i].CreatedAt, StatusEvent: &events[i]})
+	}
+
+	if isTeam {
+		config.DB.Where("application_id = ?", application.ID).Find(&application.Answers)
+
+		// Hidden applicants are shown under an alias with a redacted resume
+		if loadBlindReview(application.Job).hidden(application) {
+			var parsed models.ParsedResume
+			if err := config.DB.Where("application_id = ?", application.ID).First(&parsed).Error; err == nil {
+				application.ParsedResume = &parsed
+			}
+			applicantID := application.ApplicantID
+			redactApplication(&application)
+			for _, entry := range timeline {
+				if entry.StatusEvent.ActorID != nil && *entry.StatusEvent.ActorID == applicantID {
+					entry.StatusEvent.ActorID = nil
+				}
+			}
+		}
+
+		var notes []models.ApplicationNote
+		config.DB.Preload("Author").Where("application_id = ?", application.ID).Find(&notes)
+		for i := range notes {
+			timeline = append(timeline, ApplicationTimelineEntry{Type: "note", At: notes[i].CreatedAt, Note: &notes[i]})
+		}
+	}
+
+	// Ratings are internal, and team members see them once they have scored
+	if !isTeam || !canSeeRatings(currentUserID, application) {
+		hideRatings(&application)
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].At.Before(timeline[j].At)
+	})
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Application retrieved successfully",
+		Object:  ApplicationDetail{Application: application, Timeline: timeline},
+	})
+}
+
+func GetApplicationNotes(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	application, err := findTeamApplication(appUUID, userID.(uuid.UUID))
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var notes []models.ApplicationNote
+	if err := config.DB.Preload("Author").Where("application_id = ?", application.ID).
+		Order("created_at ASC").Find(&notes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch notes",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Notes retrieved successfully",
+		Object:  notes,
+	})
+}
+
+func CreateApplicationNote(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req ApplicationNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	authorID := userID.(uuid.UUID)
+
+	application, err := findTeamApplication(appUUID, authorID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	mentioned, err := resolveMentions(req.Body, application.Job.OrganizationID, authorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid mention",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	note := models.ApplicationNote{
+		ApplicationID:    application.ID,
+		AuthorID:         authorID,
+		Body:             strings.TrimSpace(req.Body),
+		MentionedUserIDs: userIDs(mentioned),
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&note).Error; err != nil {
+			return err
+		}
+		return notifyMentions(tx, note, application, mentioned)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create note",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	config.DB.Preload("Author").First(&note, note.ID)
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Note created successfully",
+		Object:  note,
+	})
+}
+
+// Only users mentioned for the first time by the edit are notified.
+func UpdateApplicationNote(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	noteUUID, err := uuid.Parse(c.Param("note_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid note ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req ApplicationNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	application, err := findTeamApplication(appUUID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var note models.ApplicationNote
+	if err := config.DB.Where("id = ? AND application_id = ?", noteUUID, application.ID).First(&note).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Note not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if note.AuthorID != currentUserID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Only the author can edit a note",
+			Object:  nil,
+		})
+		return
+	}
+
+	mentioned, err := resolveMentions(req.Body, application.Job.OrganizationID, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid mention",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	previous := make(map[uuid.UUID]bool)
+	for _, id := range note.MentionedUserIDs {
+		previous[id] = true
+	}
+	var newlyMentioned []models.User
+	for _, user := range mentioned {
+		if !previous[user.ID] {
+			newlyMentioned = append(newlyMentioned, user)
+		}
+	}
+
+	now := time.Now()
+	note.Body = strings.TrimSpace(req.Body)
+	note.MentionedUserIDs = userIDs(mentioned)
+	note.EditedAt = &now
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&note).Error; err != nil {
+			return err
+		}
+		return notifyMentions(tx, note, application, newlyMentioned)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update note",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	config.DB.Preload("Author").First(&note, note.ID)
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Note updated successfully",
+		Object:  note,
+	})
+}
+
+func DeleteApplicationNote(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	noteUUID, err := uuid.Parse(c.Param("note_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid note ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	application, err := findTeamApplication(appUUID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var note models.ApplicationNote
+	if err := config.DB.Where("id = ? AND application_id = ?", noteUUID, application.ID).First(&note).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Note not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if note.AuthorID != currentUserID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Only the author can delete a note",
+			Object:  nil,
+		})
+		return
+	}
+
+	if err := config.DB.Delete(&note).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete note",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Note deleted successfully",
+		Object:  nil,
+	})
+}
+
+func findTeamApplication(applicationID, userID uuid.UUID) (models.Application, error) {
+	var application models.Application
+	if err := config.DB.Preload("Job").First(&application, applicationID).Error; err != nil {
+		return application, errApplicationNotFound
+	}
+	if !canManageJob(userID, application.Job) {
+		return application, errNotHiringTeam
+	}
+	return application, nil
+}
+
+func respondApplicationAccessError(c *gin.Context, err error) {
+	if errors.Is(err, errNotHiringTeam) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized access",
+			Object:  nil,
+		})
+		return
+	}
+	c.JSON(http.StatusNotFound, models.BaseResponse{
+		Success: false,
+		Message: "Application not found",
+		Object:  nil,
+	})
+}
+
+// resolveMentions matches @handles against member emails and names.
+func resolveMentions(body string, orgID, authorID uuid.UUID) ([]models.User, error) {
+	matches := mentionPattern.FindAllStringSubmatch(body, -1)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	members, err := organizationUsers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var mentioned []models.User
+	for _, match := range matches {
+		// Handles that match nobody, such as "@3pm", are plain text
+		handle := strings.TrimRight(match[1], ".,;:")
+		for _, member := range members {
+			if strings.EqualFold(member.Email, handle) || strings.EqualFold(member.Name, handle) {
+				if member.ID != authorID && !seen[member.ID] {
+					seen[member.ID] = true
+					mentioned = append(mentioned, member)
+				}
+			}
+		}
+	}
+	return mentioned, nil
+}
+
+func notifyMentions(tx *gorm.DB, note models.ApplicationNote, application models.Application, users []models.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	notifications := make([]models.Notification, len(users))
+	for i, user := range users {
+		notifications[i] = models.Notification{
+			UserID: user.ID,
+			Type:   models.NotificationMention,
+			Title:  "You were mentioned in a note on " + application.Job.Title,
+			Body:   note.Body,
+			Data: map[string]string{
+				"application_id": application.ID.String(),
+				"note_id":        note.ID.String(),
+			},
+		}
+	}
+	return createNotifications(tx, notifications)
+}
+
+func userIDs(users []models.User) []uuid.UUID {
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"html/template"
+	"job-api/config"
+	"job-api/mailer"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpdateNotificationPreferencesRequest changes only the fields it includes.
+type UpdateNotificationPreferencesRequest struct {
+	Locale       *string                          `json:"locale" validate:"omitempty,min=2,max=10"`
+	EmailEnabled *bool                            `json:"email_enabled"`
+	Email        map[models.NotificationType]bool `json:"email"`
+	InApp        map[models.NotificationType]bool `json:"in_app"`
+}
+
+type NotificationPreferencesResponse struct {
+	Locale       string                           `json:"locale"`
+	EmailEnabled bool                             `json:"email_enabled"`
+	Email        map[models.NotificationType]bool `json:"email"`
+	InApp        map[models.NotificationType]bool `json:"in_app"`
+}
+
+func GetNotificationPreferences(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	response, err := notificationPreferences(userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch notification preferences",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Notification preferences retrieved successfully",
+		Object:  response,
+	})
+}
+
+func UpdateNotificationPreferences(c *gin.Context) {
+	var req UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	var errs []string
+	if req.Locale != nil && !mailer.HasLocale(*req.Locale) {
+		errs = append(errs, "unsupported locale "+*req.Locale)
+	}
+	for notificationType := range req.Email {
+		if !models.IsEmailNotificationType(notificationType) {
+			errs = append(errs, "unknown email notification type "+string(notificationType))
+		}
+	}
+	for notificationType := range req.InApp {
+		if !models.IsInAppNotificationType(notificationType) {
+			errs = append(errs, "unknown in-app notification type "+string(notificationType))
+		}
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	settings := loadNotificationSettings(currentUserID)
+	if req.Locale != nil {
+		settings.Locale = strings.ReplaceAll(*req.Locale, "_", "-")
+	}
+	if req.EmailEnabled != nil {
+		settings.EmailEnabled = *req.EmailEnabled
+	}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := saveNotificationSettings(tx, settings); err != nil {
+			return err
+		}
+		for notificationType, enabled := range req.Email {
+			if err := savePreference(tx, currentUserID, notificationType, &enabled, nil); err != nil {
+				return err
+			}
+		}
+		for notificationType, enabled := range req.InApp {
+			if err := savePreference(tx, currentUserID, notificationType, nil, &enabled); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update notification preferences",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := notificationPreferences(currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch notification preferences",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Notification preferences updated successfully",
+		Object:  response,
+	})
+}
+
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<p>{{if .Type}}Stop emails about {{.Type}}?{{else}}Stop all emails?{{end}}</p>
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`))
+
+// Unsubscribe links only ask for confirmation, so link scanners that follow
+// them change nothing.
+func UnsubscribeConfirmation(c *gin.Context) {
+	_, notificationType, ok := verifyUnsubscribeLink(c)
+	if !ok {
+		return
+	}
+
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	unsubscribePage.Execute(c.Writer, struct {
+		Action string
+		Type   models.NotificationType
+	}{Action: "/unsubscribe?" + c.Request.URL.RawQuery, Type: notificationType})
+}
+
+// Unsubscribe also takes RFC 8058 one-click POSTs from mail clients.
+func Unsubscribe(c *gin.Context) {
+	userUUID, notificationType, ok := verifyUnsubscribeLink(c)
+	if !ok {
+		return
+	}
+
+	var err error
+	var user models.User
+	if err := config.DB.First(&user, userUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "User not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if notificationType == "" {
+		settings := loadNotificationSettings(user.ID)
+		settings.EmailEnabled = false
+		err = saveNotificationSettings(config.DB, settings)
+	} else {
+		off := false
+		err = savePreference(config.DB, user.ID, notificationType, &off, nil)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to unsubscribe",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "You have been unsubscribed",
+		Object:  gin.H{"type": notificationType},
+	})
+}
+
+func verifyUnsubscribeLink(c *gin.Context) (uuid.UUID, models.NotificationType, bool) {
+	userUUID, err := uuid.Parse(c.Query("user"))
+	notificationType := models.NotificationType(c.Query("type"))
+	if err != nil || !utils.VerifyUnsubscribe(userUUID, string(notificationType), c.Query("signature")) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Invalid or tampered unsubscribe link",
+			Object:  nil,
+		})
+		return uuid.Nil, "", false
+	}
+	return userUUID, notificationType, true
+}
+
+func notificationPreferences(userID uuid.UUID) (NotificationPreferencesResponse, error) {
+	settings := loadNotificationSettings(userID)
+	response := NotificationPreferencesResponse{
+		Locale:       settings.Locale,
+		EmailEnabled: settings.EmailEnabled,
+		Email:        make(map[models.NotificationType]bool),
+		InApp:        make(map[models.NotificationType]bool),
+	}
+	for _, notificationType := range models.EmailNotificationTypes {
+		response.Email[notificationType] = true
+	}
+	for _, notificationType := range models.InAppNotificationTypes {
+		response.InApp[notificationType] = true
+	}
+
+	var preferences []models.NotificationPreference
+	if err := config.DB.Where("user_id = ?", userID).Find(&preferences).Error; err != nil {
+		return response, err
+	}
+	for _, preference := range preferences {
+		if _, ok := response.Email[preference.Type]; ok {
+			response.Email[preference.Type] = preference.Email
+		}
+		if _, ok := response.InApp[preference.Type]; ok {
+			response.InApp[preference.Type] = preference.InApp
+		}
+	}
+	return response, nil
+}
+
+func saveNotificationSettings(tx *gorm.DB, settings models.NotificationSettings) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"locale", "email_enabled", "updated_at"}),
+	}).Create(&settings).Error
+}
+
+// savePreference changes the channels that are given and keeps the others.
+func savePreference(tx *gorm.DB, userID uuid.UUID, notificationType models.NotificationType, email, inApp *bool) error {
+	preference := models.NotificationPreference{UserID: userID, Type: notificationType, Email: true, InApp: true}
+	if err := tx.Where("user_id = ? AND type = ?", userID, notificationType).Limit(1).Find(&preference).Error; err != nil {
+		return err
+	}
+	if email != nil {
+		preference.Email = *email
+	}
+	if inApp != nil {
+		preference.InApp = *inApp
+	}
+
+	// Select every column so false is stored rather than the column default
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "in_app", "updated_at"}),
+	}).Select("*").Create(&preference).Error
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"job-api/config"
+	"job-api/models"
+	"job-api/realtime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func GetNotifications(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	query := config.DB.Model(&models.Notification{}).Where("user_id = ?", currentUserID)
+	if unread, _ := strconv.ParseBool(c.Query("unread")); unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	query.Session(&gorm.Session{}).Count(&total)
+
+	var notifications []models.Notification
+	if err := query.Order("created_at DESC").
+		Offset(offset).Limit(pageSize).Find(&notifications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch notifications",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Success:    true,
+		Message:    "Notifications retrieved successfully",
+		Object:     notifications,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  total,
+	})
+}
+
+func GetUnreadNotificationCount(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var unread int64
+	if err := config.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID.(uuid.UUID)).
+		Count(&unread).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to count notifications",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Unread notification count retrieved successfully",
+		Object:  gin.H{"unread": unread},
+	})
+}
+
+func MarkNotificationRead(c *gin.Context) {
+	notificationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid notification ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	var notification models.Notification
+	if err := config.DB.Where("id = ? AND user_id = ?", notificationUUID, userID.(uuid.UUID)).
+		First(&notification).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Notification not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		if err := config.DB.Model(&notification).Update("read_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.BaseResponse{
+				Success: false,
+				Message: "Failed to mark notification as read",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+		notification.ReadAt = &now
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Notification marked as read",
+		Object:  notification,
+	})
+}
+
+func MarkAllNotificationsRead(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	result := config.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID.(uuid.UUID)).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to mark notifications as read",
+			Object:  nil,
+			Errors:  []string{result.Error.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Notifications marked as read",
+		Object:  gin.H{"updated": result.RowsAffected},
+	})
+}
+
+// Users who turned a type off in the app get no notification of it.
+func createNotifications(tx *gorm.DB, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	var enabled []models.Notification
+	for _, notification := range notifications {
+		var muted int64
+		if err := tx.Model(&models.NotificationPreference{}).
+			Where("user_id = ? AND type = ? AND in_app = ?", notification.UserID, notification.Type, false).
+			Count(&muted).Error; err != nil {
+			return err
+		}
+		if muted == 0 {
+			enabled = append(enabled, notification)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil
+	}
+	if err := tx.Create(&enabled).Error; err != nil {
+		return err
+	}
+
+	for _, notification := range enabled {
+		if err := realtime.Publish(tx, EventNotificationCreated, []uuid.UUID{notification.UserID}, notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1063 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"job-api/queue"
+	"job-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const taskExpireOffer = "offers.expire"
+
+// OfferRequest holds an offer's terms. Approvers sign off in the order given.
+type OfferRequest struct {
+	SalaryAmount      float64     `json:"salary_amount" validate:"required,gt=0"`
+	SalaryCurrency    string      `json:"salary_currency" validate:"required,len=3,uppercase"`
+	SalaryPeriod      string      `json:"salary_period" validate:"required,oneof=HOUR DAY WEEK MONTH YEAR"`
+	CompensationNotes string      `json:"compensation_notes" validate:"max=2000"`
+	StartDate         string      `json:"start_date" validate:"required,datetime=2006-01-02"`
+	ExpiresAt         time.Time   `json:"expires_at" validate:"required"`
+	TermsFileID       *uuid.UUID  `json:"terms_file_id"`
+	Message           string      `json:"message" validate:"max=5000"`
+	ApproverIDs       []uuid.UUID `json:"approver_ids" validate:"max=10"`
+}
+
+type OfferApprovalRequest struct {
+	Comment string `json:"comment" validate:"max=1000"`
+}
+
+type DeclineOfferRequest struct {
+	Reason string `json:"reason" validate:"max=1000"`
+}
+
+// offerTask is the payload of a queued offer expiry.
+type offerTask struct {
+	OfferID uuid.UUID `json:"offer_id"`
+}
+
+// Only one offer per application may be in progress at a time.
+func CreateOffer(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	req, ok := bindOfferRequest(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	application, err := findTeamApplication(appUUID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	if err := checkOfferableApplication(application); err != nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Cannot make an offer on this application",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	var open int64
+	config.DB.Model(&models.Offer{}).Where("application_id = ? AND status IN ?", application.ID, []models.OfferStatus{
+		models.OfferDraft, models.OfferPendingApproval, models.OfferRejected, models.OfferApproved, models.OfferSent,
+	}).Count(&open)
+	if open > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "The application already has an offer in progress",
+			Object:  nil,
+		})
+		return
+	}
+
+	offer := models.Offer{
+		ApplicationID: application.ID,
+		JobID:         application.JobID,
+		Status:        models.OfferDraft,
+		CreatedBy:     currentUserID,
+	}
+	if errs := applyOfferRequest(&offer, req, application, currentUserID); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&offer).Error; err != nil {
+			return err
+		}
+		return attachOfferTerms(tx, offer, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create offer",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	loadOffer(&offer)
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Offer created successfully",
+		Object:  offer,
+	})
+}
+
+func GetApplicationOffers(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userRole, _ := c.Get("user_role")
+	application, isTeam, err := findThreadApplication(appUUID, userID.(uuid.UUID), userRole)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	query := config.DB.Where("application_id = ?", application.ID).Preload("TermsFile")
+	if isTeam {
+		query = query.Preload("Approvals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).Preload("Approvals.Approver")
+	} else {
+		query = query.Where("sent_at IS NOT NULL")
+	}
+
+	var offers []models.Offer
+	if err := query.Order("created_at DESC").Find(&offers).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch offers",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offers retrieved successfully",
+		Object:  offers,
+	})
+}
+
+func GetOffer(c *gin.Context) {
+	userRole, _ := c.Get("user_role")
+	var offer models.Offer
+	var ok bool
+	if userRole == string(models.RoleApplicant) {
+		offer, _, ok = findApplicantOffer(c)
+	} else {
+		offer, _, ok = findTeamOffer(c)
+	}
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offer retrieved successfully",
+		Object:  offer,
+	})
+}
+
+// An edited offer goes back to a draft and has to be approved again.
+func UpdateOffer(c *gin.Context) {
+	req, ok := bindOfferRequest(c)
+	if !ok {
+		return
+	}
+
+	offer, application, ok := findTeamOffer(c)
+	if !ok {
+		return
+	}
+	if !offer.Editable() {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Offer can no longer be changed",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	previousTerms := offer.TermsFileID
+	previousStatus := offer.Status
+	if errs := applyOfferRequest(&offer, req, application, userID.(uuid.UUID)); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+	offer.Status = models.OfferDraft
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Offer{}).Where("id = ? AND status = ?", offer.ID, previousStatus).
+			Updates(map[string]interface{}{
+				"status":             offer.Status,
+				"salary_amount":      offer.SalaryAmount,
+				"salary_currency":    offer.SalaryCurrency,
+				"salary_period":      offer.SalaryPeriod,
+				"compensation_notes": offer.CompensationNotes,
+				"start_date":         offer.StartDate,
+				"expires_at":         offer.ExpiresAt,
+				"terms_file_id":      offer.TermsFileID,
+				"message":            offer.Message,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("offer was changed by another request")
+		}
+		if err := tx.Where("offer_id = ?", offer.ID).Delete(&models.OfferApproval{}).Error; err != nil {
+			return err
+		}
+		if len(offer.Approvals) > 0 {
+			if err := tx.Create(&offer.Approvals).Error; err != nil {
+				return err
+			}
+		}
+		return attachOfferTerms(tx, offer, previousTerms)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update offer",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	loadOffer(&offer)
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offer updated successfully",
+		Object:  offer,
+	})
+}
+
+// SubmitOffer starts the approval chain.
+func SubmitOffer(c *gin.Context) {
+	offer, application, ok := findTeamOffer(c)
+	if !ok {
+		return
+	}
+	if offer.Status != models.OfferDraft && offer.Status != models.OfferRejected {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Only draft or rejected offers can be submitted for approval",
+			Object:  nil,
+		})
+		return
+	}
+
+	if len(offer.Approvals) == 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Offer needs an approver before it can be submitted",
+			Object:  nil,
+		})
+		return
+	}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := updateOfferStatus(tx, &offer, models.OfferPendingApproval, nil); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.OfferApproval{}).Where("offer_id = ?", offer.ID).
+			Updates(map[string]interface{}{"status": models.OfferApprovalPending, "comment": "", "decided_at": nil}).Error; err != nil {
+			return err
+		}
+		return notifyOfferApprover(tx, offer, offer.Approvals[0], application)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to submit offer",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	loadOffer(&offer)
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offer submitted for approval",
+		Object:  offer,
+	})
+}
+
+func ApproveOffer(c *gin.Context) {
+	decideOffer(c, models.OfferApprovalApproved)
+}
+
+func RejectOffer(c *gin.Context) {
+	decideOffer(c, models.OfferApprovalRejected)
+}
+
+// Approvers decide in order; once the last one approves, the offer can be sent.
+func decideOffer(c *gin.Context, decision models.OfferApprovalStatus) {
+	// The body is optional
+	var req OfferApprovalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Invalid request data",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	offer, application, ok := findTeamOffer(c)
+	if !ok {
+		return
+	}
+	if offer.Status != models.OfferPendingApproval {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Offer is not awaiting approval",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	current, next := pendingApprovals(offer)
+	if current == nil || current.ApproverID != userID.(uuid.UUID) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Offer is awaiting approval from someone else",
+			Object:  nil,
+		})
+		return
+	}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OfferApproval{}).Where("id = ? AND status = ?", current.ID, models.OfferApprovalPending).
+			Updates(map[string]interface{}{"status": decision, "comment": strings.TrimSpace(req.Comment), "decided_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("approval was decided by another request")
+		}
+
+		if decision == models.OfferApprovalApproved && next != nil {
+			return notifyOfferApprover(tx, offer, *next, application)
+		}
+
+		status := models.OfferApproved
+		title := "Offer approved"
+		body := "The offer for " + applicantDisplayName(application) + " was approved and can be sent"
+		if decision == models.OfferApprovalRejected {
+			status = models.OfferRejected
+			title = "Offer rejected"
+			body = current.Approver.Name + " rejected the offer for " + applicantDisplayName(application)
+		}
+		if err := updateOfferStatus(tx, &offer, status, nil); err != nil {
+			return err
+		}
+		return createNotifications(tx, []models.Notification{{
+			UserID: offer.CreatedBy,
+			Type:   models.NotificationOfferApprovalUpdate,
+			Title:  title,
+			Body:   body,
+			Data:   offerNotificationData(offer),
+		}})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to record approval",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	loadOffer(&offer)
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Approval recorded successfully",
+		Object:  offer,
+	})
+}
+
+func SendOffer(c *gin.Context) {
+	offer, application, ok := findTeamOffer(c)
+	if !ok {
+		return
+	}
+	if offer.Status != models.OfferApproved {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Only approved offers can be sent",
+			Object:  nil,
+		})
+		return
+	}
+	if !offer.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Offer expiry has passed; update the offer before sending it",
+			Object:  nil,
+		})
+		return
+	}
+	if err := checkOfferableApplication(application); err != nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Cannot send an offer on this application",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	var job models.Job
+	config.DB.Preload("Creator").First(&job, offer.JobID)
+
+	now := time.Now()
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := updateOfferStatus(tx, &offer, models.OfferSent, map[string]interface{}{"sent_at": now}); err != nil {
+			return err
+		}
+		offer.SentAt = &now
+		if err := queue.EnqueueAt(tx, taskExpireOffer, offerTask{OfferID: offer.ID}, offer.ExpiresAt); err != nil {
+			return err
+		}
+		if err := createNotifications(tx, []models.Notification{{
+			UserID: application.ApplicantID,
+			Type:   models.NotificationOfferReceived,
+			Title:  "Offer for " + job.Title,
+			Body:   job.Creator.Name + " made you an offer for " + job.Title + ". It expires on " + formatEmailTime(offer.ExpiresAt),
+			Data:   offerNotificationData(offer),
+		}}); err != nil {
+			return err
+		}
+		return queueNotificationEmails(tx, notificationEmail{
+			UserID: application.ApplicantID,
+			Type:   models.NotificationOfferReceived,
+			Data: map[string]string{
+				"job_title":    job.Title,
+				"company_name": job.Creator.Name,
+				"compensation": formatCompensation(offer),
+				"start_date":   offer.StartDate.Format("2006-01-02"),
+				"expires_at":   formatEmailTime(offer.ExpiresAt),
+				"message":      offer.Message,
+			},
+		})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to send offer",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	loadOffer(&offer)
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offer sent successfully",
+		Object:  offer,
+	})
+}
+
+// WithdrawOffer cancels an offer the applicant has not answered yet.
+func WithdrawOffer(c *gin.Context) {
+	offer, application, ok := findTeamOffer(c)
+	if !ok {
+		return
+	}
+	if !offer.Open() {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Offer can no longer be withdrawn",
+			Object:  nil,
+		})
+		return
+	}
+
+	var job models.Job
+	config.DB.First(&job, offer.JobID)
+
+	wasSent := offer.Status == models.OfferSent
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := updateOfferStatus(tx, &offer, models.OfferWithdrawn, nil); err != nil {
+			return err
+		}
+		if !wasSent {
+			return nil
+		}
+		return createNotifications(tx, []models.Notification{{
+			UserID: application.ApplicantID,
+			Type:   models.NotificationOfferWithdrawn,
+			Title:  "Offer withdrawn",
+			Body:   "The offer for " + job.Title + " was withdrawn",
+			Data:   offerNotificationData(offer),
+		}})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to withdraw offer",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	loadOffer(&offer)
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offer withdrawn successfully",
+		Object:  offer,
+	})
+}
+
+func AcceptOffer(c *gin.Context) {
+	offer, application, ok := findApplicantOffer(c)
+	if !ok || !checkOfferAnswerable(c, &offer) {
+		return
+	}
+	if err := checkOfferableApplication(application); err != nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Offer can no longer be accepted",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	pipeline, err := loadJobPipeline(application.Job)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to load job pipeline",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	hired, ok := pipeline.HiredStage()
+	if !ok {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Job pipeline has no hired stage",
+			Object:  nil,
+		})
+		return
+	}
+
+	now := time.Now()
+	jobClosed := false
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := updateOfferStatus(tx, &offer, models.OfferAccepted, map[string]interface{}{"responded_at": now}); err != nil {
+			return err
+		}
+
+		// An accepted offer is a hire, whichever stage the application was in
+		event := models.ApplicationStatusEvent{
+			ApplicationID: application.ID,
+			FromStatus:    application.Status,
+			ToStatus:      models.ApplicationStatus(hired.Name),
+			FromStageID:   application.StageID,
+			ToStageID:     &hired.ID,
+			ActorID:       &application.ApplicantID,
+			Reason:        "Offer accepted",
+		}
+		if err := changeApplicationStatus(tx, application, event); err != nil {
+			return err
+		}
+
+		jobClosed, err = closeFilledJob(tx, application.Job)
+		if err != nil {
+			return err
+		}
+		body := application.Applicant.Name + " accepted the offer for " + application.Job.Title
+		if jobClosed {
+			body += ". The job's headcount is filled and it has been closed"
+		}
+		return createNotifications(tx, []models.Notification{{
+			UserID: offer.CreatedBy,
+			Type:   models.NotificationOfferResponded,
+			Title:  "Offer accepted",
+			Body:   body,
+			Data:   offerNotificationData(offer),
+		}})
+	})
+	if errors.Is(err, errStatusConflict) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Application was updated by another request, please retry",
+			Object:  nil,
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to accept offer",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	offer.RespondedAt = &now
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offer accepted successfully",
+		Object:  offer,
+	})
+}
+
+func DeclineOffer(c *gin.Context) {
+	// The body is optional
+	var req DeclineOfferRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Invalid request data",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	offer, application, ok := findApplicantOffer(c)
+	if !ok || !checkOfferAnswerable(c, &offer) {
+		return
+	}
+
+	now := time.Now()
+	reason := strings.TrimSpace(req.Reason)
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := updateOfferStatus(tx, &offer, models.OfferDeclined, map[string]interface{}{
+			"responded_at":   now,
+			"decline_reason": reason,
+		}); err != nil {
+			return err
+		}
+		body := applicantDisplayName(application) + " declined the offer for " + application.Job.Title
+		if reason != "" {
+			body += ": " + reason
+		}
+		return createNotifications(tx, []models.Notification{{
+			UserID: offer.CreatedBy,
+			Type:   models.NotificationOfferResponded,
+			Title:  "Offer declined",
+			Body:   body,
+			Data:   offerNotificationData(offer),
+		}})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to decline offer",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	offer.RespondedAt = &now
+	offer.DeclineReason = reason
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Offer declined",
+		Object:  offer,
+	})
+}
+
+// expireOfferTask expires a sent offer the applicant did not answer in time.
+func expireOfferTask(ctx context.Context, task models.QueueTask) error {
+	var payload offerTask
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return queue.Permanent(err)
+	}
+
+	var offer models.Offer
+	if err := config.DB.First(&offer, payload.OfferID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if offer.Status != models.OfferSent {
+		return nil
+	}
+	if time.Now().Before(offer.ExpiresAt) {
+		return errors.New("offer has not expired yet")
+	}
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		return expireOffer(tx, offer)
+	})
+}
+
+func expireOffer(tx *gorm.DB, offer models.Offer) error {
+	result := tx.Model(&models.Offer{}).Where("id = ? AND status = ?", offer.ID, models.OfferSent).
+		Update("status", models.OfferExpired)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	var application models.Application
+	if err := tx.Preload("Applicant").Preload("Job").First(&application, offer.ApplicationID).Error; err != nil {
+		return err
+	}
+	return createNotifications(tx, []models.Notification{{
+		UserID: offer.CreatedBy,
+		Type:   models.NotificationOfferResponded,
+		Title:  "Offer expired",
+		Body:   "The offer to " + applicantDisplayName(application) + " for " + application.Job.Title + " expired without an answer",
+		Data:   offerNotificationData(offer),
+	}})
+}
+
+// checkOfferAnswerable expires offers whose time is up.
+func checkOfferAnswerable(c *gin.Context, offer *models.Offer) bool {
+	if offer.Status == models.OfferSent && !offer.ExpiresAt.After(time.Now()) {
+		config.DB.Transaction(func(tx *gorm.DB) error {
+			return expireOffer(tx, *offer)
+		})
+		offer.Status = models.OfferExpired
+	}
+	if offer.Status != models.OfferSent {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Offer is " + strings.ReplaceAll(string(offer.Status), "_", " ") + " and can no longer be answered",
+			Object:  nil,
+		})
+		return false
+	}
+	return true
+}
+
+func checkOfferableApplication(application models.Application) error {
+	if application.Status == models.StatusWithdrawn {
+		return errors.New("application has been withdrawn by the applicant")
+	}
+	if application.StageID == nil {
+		return nil
+	}
+	var stage models.PipelineStage
+	if err := config.DB.First(&stage, *application.StageID).Error; err != nil {
+		return err
+	}
+	if stage.IsTerminal() {
+		return errors.New("application is in the final stage " + stage.Name)
+	}
+	return nil
+}
+
+func closeFilledJob(tx *gorm.DB, job models.Job) (bool, error) {
+	// Lock the job so offers accepted at the same time are counted together
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, job.ID).Error; err != nil {
+		return false, err
+	}
+	if !job.CloseWhenFilled || job.Headcount == nil || *job.Headcount < 1 || job.Status == models.JobStatusClosed {
+		return false, nil
+	}
+
+	var accepted int64
+	if err := tx.Model(&models.Offer{}).Where("job_id = ? AND status = ?", job.ID, models.OfferAccepted).
+		Count(&accepted).Error; err != nil {
+		return false, err
+	}
+	if accepted < int64(*job.Headcount) {
+		return false, nil
+	}
+	result := tx.Model(&models.Job{}).Where("id = ? AND status <> ?", job.ID, models.JobStatusClosed).
+		Update("status", models.JobStatusClosed)
+	return result.RowsAffected > 0, result.Error
+}
+
+func applyOfferRequest(offer *models.Offer, req OfferRequest, application models.Application, userID uuid.UUID) []string {
+	var errs []string
+
+	startDate, _ := time.Parse("2006-01-02", req.StartDate)
+	if !req.ExpiresAt.After(time.Now()) {
+		errs = append(errs, "expires_at must be in the future")
+	}
+
+	if req.TermsFileID != nil && (offer.TermsFileID == nil || *offer.TermsFileID != *req.TermsFileID) {
+		if _, err := loadMessageAttachments(userID, []uuid.UUID{*req.TermsFileID}); err != nil {
+			errs = append(errs, "terms_file_id: "+err.Error())
+		}
+	}
+
+	// Someone besides the people who wrote the terms has to sign off
+	approvers := uniqueIDs(req.ApproverIDs)
+	approvals := make([]models.OfferApproval, 0, len(approvers))
+	independent := false
+	for i, approverID := range approvers {
+		if !canManageJob(approverID, application.Job) {
+			errs = append(errs, fmt.Sprintf("approver %s is not on the job's hiring team", approverID))
+			continue
+		}
+		if approverID != offer.CreatedBy && approverID != userID {
+			independent = true
+		}
+		approvals = append(approvals, models.OfferApproval{
+			OfferID:    offer.ID,
+			ApproverID: approverID,
+			Position:   i,
+			Status:     models.OfferApprovalPending,
+		})
+	}
+	if !independent {
+		errs = append(errs, "approver_ids must include someone other than the offer's author")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	offer.SalaryAmount = req.SalaryAmount
+	offer.SalaryCurrency = req.SalaryCurrency
+	offer.SalaryPeriod = req.SalaryPeriod
+	offer.CompensationNotes = strings.TrimSpace(req.CompensationNotes)
+	offer.StartDate = startDate
+	offer.ExpiresAt = req.ExpiresAt
+	offer.TermsFileID = req.TermsFileID
+	offer.Message = strings.TrimSpace(req.Message)
+	offer.Approvals = approvals
+	return nil
+}
+
+func attachOfferTerms(tx *gorm.DB, offer models.Offer, previous *uuid.UUID) error {
+	if previous != nil && (offer.TermsFileID == nil || *previous != *offer.TermsFileID) {
+		if err := tx.Model(&models.File{}).Where("id = ?", *previous).
+			Updates(map[string]interface{}{"application_id": nil, "offer_id": nil}).Error; err != nil {
+			return err
+		}
+	}
+	if offer.TermsFileID == nil {
+		return nil
+	}
+	return tx.Model(&models.File{}).Where("id = ?", *offer.TermsFileID).
+		Updates(map[string]interface{}{"application_id": offer.ApplicationID, "offer_id": offer.ID}).Error
+}
+
+// updateOfferStatus fails if another request moved the offer first.
+func updateOfferStatus(tx *gorm.DB, offer *models.Offer, status models.OfferStatus, updates map[string]interface{}) error {
+	if updates == nil {
+		updates = map[string]interface{}{}
+	}
+	updates["status"] = status
+	result := tx.Model(&models.Offer{}).Where("id = ? AND status = ?", offer.ID, offer.Status).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("offer was changed by another request")
+	}
+	offer.Status = status
+	return nil
+}
+
+// Approvals must be loaded in order.
+func pendingApprovals(offer models.Offer) (*models.OfferApproval, *models.OfferApproval) {
+	for i := range offer.Approvals {
+		if offer.Approvals[i].Status != models.OfferApprovalPending {
+			continue
+		}
+		if i+1 < len(offer.Approvals) {
+			return &offer.Approvals[i], &offer.Approvals[i+1]
+		}
+		return &offer.Approvals[i], nil
+	}
+	return nil, nil
+}
+
+func notifyOfferApprover(tx *gorm.DB, offer models.Offer, approval models.OfferApproval, application models.Application) error {
+	return createNotifications(tx, []models.Notification{{
+		UserID: approval.ApproverID,
+		Type:   models.NotificationOfferApprovalRequest,
+		Title:  "Offer awaiting your approval",
+		Body:   "The offer for " + applicantDisplayName(application) + " on " + application.Job.Title + " needs your approval",
+		Data:   offerNotificationData(offer),
+	}})
+}
+
+func offerNotificationData(offer models.Offer) map[string]string {
+	return map[string]string{
+		"offer_id":       offer.ID.String(),
+		"application_id": offer.ApplicationID.String(),
+		"job_id":         offer.JobID.String(),
+	}
+}
+
+func formatCompensation(offer models.Offer) string {
+	return strconv.FormatFloat(offer.SalaryAmount, 'f', -1, 64) + " " + offer.SalaryCurrency + " per " +
+		strings.ToLower(offer.SalaryPeriod)
+}
+
+func bindOfferRequest(c *gin.Context) (OfferRequest, bool) {
+	var req OfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return req, false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return req, false
+	}
+	return req, true
+}
+
+func findTeamOffer(c *gin.Context) (models.Offer, models.Application, bool) {
+	var offer models.Offer
+	var application models.Application
+	offerUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid offer ID",
+			Object:  nil,
+		})
+		return offer, application, false
+	}
+
+	if err := config.DB.First(&offer, offerUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Offer not found",
+			Object:  nil,
+		})
+		return offer, application, false
+	}
+
+	userID, _ := c.Get("user_id")
+	application, err = findTeamApplication(offer.ApplicationID, userID.(uuid.UUID))
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return offer, application, false
+	}
+	config.DB.First(&application.Applicant, application.ApplicantID)
+	loadOffer(&offer)
+	return offer, application, true
+}
+
+func findApplicantOffer(c *gin.Context) (models.Offer, models.Application, bool) {
+	var offer models.Offer
+	var application models.Application
+	offerUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid offer ID",
+			Object:  nil,
+		})
+		return offer, application, false
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := config.DB.Preload("TermsFile").Where("id = ? AND sent_at IS NOT NULL", offerUUID).First(&offer).Error; err != nil ||
+		config.DB.Preload("Applicant").Preload("Job").
+			Where("id = ? AND applicant_id = ?", offer.ApplicationID, userID.(uuid.UUID)).
+			First(&application).Error != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Offer not found",
+			Object:  nil,
+		})
+		return offer, application, false
+	}
+	return offer, application, true
+}
+
+// loadOffer reloads an offer with its terms document and ordered approvals.
+func loadOffer(offer *models.Offer) {
+	config.DB.Preload("TermsFile").Preload("Approvals", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Preload("Approvals.Approver").First(offer, offer.ID)
+}
@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+func InviteOrganizationMember(c *gin.Context) {
+	var req AddOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	ownerID := userID.(uuid.UUID)
+
+	if organizationID(ownerID) != ownerID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Only the organization account can manage members",
+			Object:  nil,
+		})
+		return
+	}
+
+	var user models.User
+	if err := config.DB.Where("LOWER(email) = ?", strings.ToLower(req.Email)).First(&user).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "User not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if user.Role != models.RoleCompany || user.ID == ownerID {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Only other company users can be added as members",
+			Object:  nil,
+		})
+		return
+	}
+
+	if err := checkJoinable(user.ID); err != nil {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "User cannot be invited",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	var pending int64
+	config.DB.Model(&models.OrganizationInvitation{}).
+		Where("organization_id = ? AND user_id = ? AND status = ?", ownerID, user.ID, models.OrganizationInvitationPending).
+		Count(&pending)
+	if pending > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "User has already been invited",
+			Object:  nil,
+		})
+		return
+	}
+
+	var organization models.User
+	config.DB.First(&organization, ownerID)
+
+	invitation := models.OrganizationInvitation{
+		OrganizationID: ownerID,
+		UserID:         user.ID,
+		Status:         models.OrganizationInvitationPending,
+		InvitedBy:      ownerID,
+	}
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&invitation).Error; err != nil {
+			return err
+		}
+		return createNotifications(tx, []models.Notification{{
+			UserID: user.ID,
+			Type:   models.NotificationOrganizationInvite,
+			Title:  "Join " + organization.Name + "?",
+			Body:   organization.Name + " invited you to join their hiring team.",
+			Data:   map[string]string{"invitation_id": invitation.ID.String()},
+		}})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to invite member",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	invitation.User = user
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Member invited successfully",
+		Object:  invitation,
+	})
+}
+
+func GetOrganizationInvitations(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var invitations []models.OrganizationInvitation
+	if err := config.DB.Preload("Organization").
+		Where("user_id = ? AND status = ?", userID.(uuid.UUID), models.OrganizationInvitationPending).
+		Order("created_at DESC").Find(&invitations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch invitations",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Invitations retrieved successfully",
+		Object:  invitations,
+	})
+}
+
+func AcceptOrganizationInvitation(c *gin.Context) {
+	answerOrganizationInvitation(c, true)
+}
+
+func DeclineOrganizationInvitation(c *gin.Context) {
+	answerOrganizationInvitation(c, false)
+}
+
+func answerOrganizationInvitation(c *gin.Context, accept bool) {
+	invitationUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid invitation ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var invitation models.OrganizationInvitation
+	if err := config.DB.Where("id = ? AND user_id = ?", invitationUUID, currentUserID).
+		First(&invitation).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Invitation not found",
+			Object:  nil,
+		})
+		return
+	}
+	if invitation.Status != models.OrganizationInvitationPending {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Invitation has already been answered",
+			Object:  nil,
+		})
+		return
+	}
+
+	if accept {
+		var existing int64
+		config.DB.Model(&models.OrganizationMember{}).Where("user_id = ?", currentUserID).Count(&existing)
+		if existing > 0 {
+			c.JSON(http.StatusConflict, models.BaseResponse{
+				Success: false,
+				Message: "You already belong to an organization",
+				Object:  nil,
+			})
+			return
+		}
+		if err := checkJoinable(currentUserID); err != nil {
+			c.JSON(http.StatusConflict, models.BaseResponse{
+				Success: false,
+				Message: "Cannot join the organization",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	status := models.OrganizationInvitationDeclined
+	if accept {
+		status = models.OrganizationInvitationAccepted
+	}
+	now := time.Now()
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OrganizationInvitation{}).
+			Where("id = ? AND status = ?", invitation.ID, models.OrganizationInvitationPending).
+			Updates(map[string]interface{}{"status": status, "responded_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("invitation was answered by another request")
+		}
+		if !accept {
+			return nil
+		}
+		return tx.Create(&models.OrganizationMember{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         currentUserID,
+			AddedBy:        invitation.InvitedBy,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to answer invitation",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	invitation.Status = status
+	invitation.RespondedAt = &now
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Invitation answered successfully",
+		Object:  invitation,
+	})
+}
+
+// Joining would hand an account's own jobs and hiring data to the
+// organization, so accounts that have any cannot join.
+func checkJoinable(userID uuid.UUID) error {
+	owned := []struct {
+		model interface{}
+		query string
+		what  string
+	}{
+		{&models.Job{}, "organization_id = ?", "jobs"},
+		{&models.OrganizationMember{}, "organization_id = ?", "organization members"},
+		{&models.Tag{}, "organization_id = ?", "tags"},
+		{&models.MessageTemplate{}, "organization_id = ?", "message templates"},
+		{&models.TalentPoolCandidate{}, "organization_id = ?", "a talent pool"},
+		{&models.WebhookEndpoint{}, "organization_id = ?", "webhooks"},
+	}
+	for _, entry := range owned {
+		var count int64
+		if err := config.DB.Model(entry.model).Where(entry.query, userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("the account already has " + entry.what + " of its own")
+		}
+	}
+	return nil
+}
+
+func GetOrganizationMembers(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	orgID := organizationID(userID.(uuid.UUID))
+
+	var members []models.OrganizationMember
+	if err := config.DB.Preload("User").Where("organization_id = ?", orgID).
+		Order("created_at ASC").Find(&members).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch members",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Members retrieved successfully",
+		Object:  members,
+	})
+}
+
+func RemoveOrganizationMember(c *gin.Context) {
+	memberUserID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid user ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	ownerID := userID.(uuid.UUID)
+
+	// Pending invitations are withdrawn the same way
+	withdrawn := config.DB.Where("organization_id = ? AND user_id = ? AND status = ?", ownerID, memberUserID, models.OrganizationInvitationPending).
+		Delete(&models.OrganizationInvitation{})
+	result := config.DB.Where("organization_id = ? AND user_id = ?", ownerID, memberUserID).
+		Delete(&models.OrganizationMember{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to remove member",
+			Object:  nil,
+			Errors:  []string{result.Error.Error()},
+		})
+		return
+	}
+	if result.RowsAffected == 0 && withdrawn.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Member not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Member removed successfully",
+		Object:  nil,
+	})
+}
+
+// Users who are not a member of an organization are their own.
+func organizationID(userID uuid.UUID) uuid.UUID {
+	var member models.OrganizationMember
+	if err := config.DB.Where("user_id = ?", userID).First(&member).Error; err != nil {
+		return userID
+	}
+	return member.OrganizationID
+}
+
+func canManageJob(userID uuid.UUID, job models.Job) bool {
+	return job.OrganizationID == organizationID(userID)
+}
+
+func organizationJobIDs(orgID uuid.UUID) *gorm.DB {
+	return config.DB.Model(&models.Job{}).Select("id").Where("organization_id = ?", orgID)
+}
+
+func organizationUsers(orgID uuid.UUID) ([]models.User, error) {
+	var users []models.User
+	err := config.DB.Where("id = ? OR id IN (?)", orgID,
+		config.DB.Model(&models.OrganizationMember{}).Select("user_id").Where("organization_id = ?", orgID)).
+		Find(&users).Error
+	return users, err
+}
@@ -0,0 +1,427 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PipelineStageRequest struct {
+	ID       *uuid.UUID           `json:"id"`
+	Name     string               `json:"name" validate:"required,min=1,max=100"`
+	Category models.StageCategory `json:"category" validate:"required,oneof=active rejected hired"`
+}
+
+type PipelineRequest struct {
+	Name   string                 `json:"name" validate:"required,min=1,max=100"`
+	Stages []PipelineStageRequest `json:"stages" validate:"required,min=1,max=30,dive"`
+}
+
+var (
+	errPipelineNotFound = errors.New("pipeline not found")
+	errStageInUse       = errors.New("stage is in use")
+)
+
+func CreatePipeline(c *gin.Context) {
+	var req PipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	companyID := userID.(uuid.UUID)
+
+	pipeline := models.Pipeline{
+		CompanyID: &companyID,
+		Name:      req.Name,
+	}
+	for i, stage := range req.Stages {
+		pipeline.Stages = append(pipeline.Stages, models.PipelineStage{
+			Name:     strings.TrimSpace(stage.Name),
+			Position: i,
+			Category: stage.Category,
+		})
+	}
+
+	if err := models.ValidateStages(pipeline.Stages); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := config.DB.Create(&pipeline).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create pipeline",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	pipeline.Stages = pipeline.OrderedStages()
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Pipeline created successfully",
+		Object:  pipeline,
+	})
+}
+
+func GetPipelines(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	companyID := userID.(uuid.UUID)
+
+	var pipelines []models.Pipeline
+	if err := config.DB.Preload("Stages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).
+		Where("company_id = ? OR (company_id IS NULL AND is_default = ?)", companyID, true).
+		Order("is_default DESC, created_at ASC").
+		Find(&pipelines).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch pipelines",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Pipelines retrieved successfully",
+		Object:  pipelines,
+	})
+}
+
+func GetPipeline(c *gin.Context) {
+	pipelineUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid pipeline ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	companyID := userID.(uuid.UUID)
+
+	pipeline, err := loadPipelineForCompany(pipelineUUID, companyID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Pipeline not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	pipeline.Stages = pipeline.OrderedStages()
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Pipeline retrieved successfully",
+		Object:  pipeline,
+	})
+}
+
+// Omitted stages are removed if no application is in them.
+func UpdatePipeline(c *gin.Context) {
+	pipelineUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid pipeline ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req PipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	companyID := userID.(uuid.UUID)
+
+	pipeline, err := loadPipelineForCompany(pipelineUUID, companyID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Pipeline not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if pipeline.CompanyID == nil || *pipeline.CompanyID != companyID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "The default pipeline cannot be modified",
+			Object:  nil,
+		})
+		return
+	}
+
+	existing := make(map[uuid.UUID]models.PipelineStage)
+	for _, stage := range pipeline.Stages {
+		existing[stage.ID] = stage
+	}
+
+	var stages []models.PipelineStage
+	kept := make(map[uuid.UUID]bool)
+	for i, stageReq := range req.Stages {
+		stage := models.PipelineStage{PipelineID: pipeline.ID}
+		if stageReq.ID != nil {
+			current, ok := existing[*stageReq.ID]
+			if !ok || kept[current.ID] {
+				c.JSON(http.StatusBadRequest, models.BaseResponse{
+					Success: false,
+					Message: "Validation failed",
+					Object:  nil,
+					Errors:  []string{fmt.Sprintf("stage %s does not belong to this pipeline", stageReq.ID)},
+				})
+				return
+			}
+			stage = current
+			kept[current.ID] = true
+		}
+		stage.Name = strings.TrimSpace(stageReq.Name)
+		stage.Category = stageReq.Category
+		stage.Position = i
+		stages = append(stages, stage)
+	}
+
+	if err := models.ValidateStages(stages); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	var removed []uuid.UUID
+	for id := range existing {
+		if !kept[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the pipeline against concurrent edits and the removed stages
+		// against applications moving into them, then check they are empty
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Pipeline{}, pipeline.ID).Error; err != nil {
+			return err
+		}
+		if len(removed) > 0 {
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", removed).
+				Find(&[]models.PipelineStage{}).Error; err != nil {
+				return err
+			}
+			for _, id := range removed {
+				var count int64
+				if err := tx.Model(&models.Application{}).Where("stage_id = ?", id).Count(&count).Error; err != nil {
+					return err
+				}
+				if count > 0 {
+					return fmt.Errorf("%w: stage %s still has %d applications", errStageInUse, existing[id].Name, count)
+				}
+			}
+
+			if err := tx.Where("id IN ?", removed).Delete(&models.PipelineStage{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range stages {
+			if err := tx.Save(&stages[i]).Error; err != nil {
+				return err
+			}
+			// Applications carry the stage name as their status, except withdrawn ones
+			if previous, ok := existing[stages[i].ID]; ok && previous.Name != stages[i].Name {
+				if err := tx.Model(&models.Application{}).
+					Where("stage_id = ? AND status <> ?", stages[i].ID, models.StatusWithdrawn).
+					UpdateColumn("status", stages[i].Name).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Model(&pipeline).Update("name", req.Name).Error
+	})
+	if errors.Is(err, errStageInUse) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Stage is in use",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update pipeline",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	pipeline.Stages = stages
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Pipeline updated successfully",
+		Object:  pipeline,
+	})
+}
+
+// lockStage keeps a stage from being removed until the transaction placing
+// an application in it ends. UpdatePipeline locks stages it removes before
+// checking they are empty, so one of the two waits for the other.
+func lockStage(tx *gorm.DB, stageID uuid.UUID) error {
+	var stage models.PipelineStage
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&stage, stageID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("stage was removed from the pipeline")
+	}
+	return err
+}
+
+func DeletePipeline(c *gin.Context) {
+	pipelineUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid pipeline ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	companyID := userID.(uuid.UUID)
+
+	pipeline, err := loadPipelineForCompany(pipelineUUID, companyID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Pipeline not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if pipeline.CompanyID == nil || *pipeline.CompanyID != companyID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "The default pipeline cannot be deleted",
+			Object:  nil,
+		})
+		return
+	}
+
+	var jobCount int64
+	config.DB.Model(&models.Job{}).Where("pipeline_id = ?", pipeline.ID).Count(&jobCount)
+	if jobCount > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Pipeline is in use",
+			Object:  nil,
+			Errors:  []string{fmt.Sprintf("pipeline is attached to %d jobs", jobCount)},
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("pipeline_id = ?", pipeline.ID).Delete(&models.PipelineStage{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&pipeline).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete pipeline",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Pipeline deleted successfully",
+		Object:  nil,
+	})
+}
+
+func loadPipelineForCompany(pipelineID uuid.UUID, companyID uuid.UUID) (models.Pipeline, error) {
+	var pipeline models.Pipeline
+	if err := config.DB.Preload("Stages").First(&pipeline, pipelineID).Error; err != nil {
+		return pipeline, errPipelineNotFound
+	}
+	if pipeline.CompanyID == nil && pipeline.IsDefault {
+		return pipeline, nil
+	}
+	if pipeline.CompanyID == nil || *pipeline.CompanyID != companyID {
+		return pipeline, errPipelineNotFound
+	}
+	return pipeline, nil
+}
+
+func resolveJobPipeline(pipelineID *uuid.UUID, companyID uuid.UUID) (models.Pipeline, error) {
+	if pipelineID == nil {
+		return models.DefaultPipeline(config.DB)
+	}
+	return loadPipelineForCompany(*pipelineID, companyID)
+}
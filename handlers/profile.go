@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UpdateProfileRequest struct {
+	Headline           string   `json:"headline" validate:"max=200"`
+	Skills             []string `json:"skills" validate:"max=50,dive,min=1,max=50"`
+	PreferredLocations []string `json:"preferred_locations" validate:"max=10,dive,min=1,max=100"`
+}
+
+func GetMyProfile(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var profile models.ApplicantProfile
+	if err := config.DB.Where("user_id = ?", currentUserID).First(&profile).Error; err != nil {
+		profile = models.ApplicantProfile{UserID: currentUserID}
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Profile retrieved successfully",
+		Object:  profile,
+	})
+}
+
+func UpdateMyProfile(c *gin.Context) {
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var profile models.ApplicantProfile
+	if err := config.DB.Where("user_id = ?", currentUserID).First(&profile).Error; err != nil {
+		profile = models.ApplicantProfile{UserID: currentUserID}
+	}
+
+	profile.Headline = req.Headline
+	profile.Skills = utils.NormalizeSkills(req.Skills)
+	profile.PreferredLocations = req.PreferredLocations
+
+	if err := config.DB.Save(&profile).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update profile",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Profile updated successfully",
+		Object:  profile,
+	})
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"job-api/config"
+	"job-api/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	publicMaxPageSize  = 50
+	publicCacheControl = "public, max-age=300, s-maxage=900, stale-while-revalidate=3600"
+)
+
+func PublicListJobs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > publicMaxPageSize {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	query := config.DB.Model(&models.Job{}).Preload("Creator").Scopes(models.PublishedJobs, filterJobs(c))
+
+	var total int64
+	query.Count(&total)
+
+	var jobs []models.Job
+	if err := query.Order("jobs.created_at DESC").Offset(offset).Limit(pageSize).Find(&jobs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch jobs",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	publicJobs := make([]models.PublicJob, len(jobs))
+	for i, job := range jobs {
+		publicJobs[i] = models.NewPublicJob(job)
+	}
+
+	writeCachedJSON(c, jobBoardModified(), models.PaginatedResponse{
+		Success:    true,
+		Message:    "Jobs retrieved successfully",
+		Object:     publicJobs,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  total,
+	})
+}
+
+func PublicGetJob(c *gin.Context) {
+	jobID := c.Param("id")
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var job models.Job
+	if err := config.DB.Preload("Creator").
+		Scopes(models.PublishedJobs).
+		First(&job, jobUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Job not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	writeCachedJSON(c, job.UpdatedAt, models.BaseResponse{
+		Success: true,
+		Message: "Job details retrieved successfully",
+		Object:  models.NewPublicJob(job),
+	})
+}
+
+// Lists change when a job leaves them, so deleted, closed and expired jobs
+// count too.
+func jobBoardModified() time.Time {
+	var lastModified *time.Time
+	now := time.Now()
+	config.DB.Raw(`SELECT GREATEST(
+		(SELECT MAX(GREATEST(updated_at, CASE WHEN valid_through <= ? THEN valid_through END)) FROM jobs),
+		(SELECT MAX(deleted_at) FROM deleted_jobs))`, now).Scan(&lastModified)
+	if lastModified == nil {
+		return time.Time{}
+	}
+	return *lastModified
+}
+
+// writeCachedJSON writes a publicly cacheable JSON response.
+func writeCachedJSON(c *gin.Context, lastModified time.Time, response interface{}) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to encode response",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	writeCached(c, lastModified, "application/json; charset=utf-8", body)
+}
+
+func writeCached(c *gin.Context, lastModified time.Time, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("Cache-Control", publicCacheControl)
+	c.Header("ETag", etag)
+	c.Header("Vary", "Accept-Encoding")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		if match == etag || match == "*" {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	} else if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !lastModified.IsZero() {
+		if !lastModified.Truncate(time.Second).After(since) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
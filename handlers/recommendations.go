@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	recommendationQueryTerms    = 30
+	recommendationCandidatePool = 200
+)
+
+// Weights of the recommendation score components; they sum to 1.
+const (
+	textWeight     = 0.45
+	skillWeight    = 0.30
+	locationWeight = 0.15
+	companyWeight  = 0.10
+)
+
+type JobRecommendation struct {
+	Job     models.Job `json:"job"`
+	Score   float64    `json:"score"`
+	Reasons []string   `json:"reasons"`
+}
+
+type termMatch struct {
+	JobID  uuid.UUID
+	Term   string
+	Weight float64
+}
+
+func GetRecommendedJobs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	userID, _ := c.Get("user_id")
+	applicantID := userID.(uuid.UUID)
+
+	fail := func(err error) {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch recommended jobs",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+	}
+
+	var profile models.ApplicantProfile
+	if err := config.DB.Where("user_id = ?", applicantID).Limit(1).Find(&profile).Error; err != nil {
+		fail(err)
+		return
+	}
+
+	var applications []models.Application
+	if err := config.DB.Where("applicant_id = ?", applicantID).Preload("Job").Find(&applications).Error; err != nil {
+		fail(err)
+		return
+	}
+
+	var bookmarks []models.Bookmark
+	if err := config.DB.Where("user_id = ?", applicantID).Preload("Job").Find(&bookmarks).Error; err != nil {
+		fail(err)
+		return
+	}
+
+	// Build the applicant's interest document from profile and history
+	var appliedJobIDs []uuid.UUID
+	applied := make(map[uuid.UUID]bool)
+	interestedCompanies := make(map[uuid.UUID]bool)
+	interestText := []string{profile.Headline, strings.Join(profile.Skills, " ")}
+	for _, app := range applications {
+		appliedJobIDs = append(appliedJobIDs, app.JobID)
+		applied[app.JobID] = true
+		interestedCompanies[app.Job.OrganizationID] = true
+		interestText = append(interestText, app.Job.Title, app.Job.Title, app.Job.Description)
+	}
+	for _, bookmark := range bookmarks {
+		interestedCompanies[bookmark.Job.OrganizationID] = true
+		interestText = append(interestText, bookmark.Job.Title, bookmark.Job.Title, bookmark.Job.Description)
+	}
+
+	queryWeights, idf, err := weighQueryTerms(models.TermWeights(strings.Join(interestText, " ")), recommendationQueryTerms)
+	var textScores map[uuid.UUID]float64
+	var sharedTerms map[uuid.UUID][]string
+	if err == nil {
+		textScores, sharedTerms, err = matchJobTerms(queryWeights, idf, appliedJobIDs)
+	}
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	candidateIDs := make([]uuid.UUID, 0, len(textScores))
+	var maxTextScore float64
+	for id, score := range textScores {
+		candidateIDs = append(candidateIDs, id)
+		if score > maxTextScore {
+			maxTextScore = score
+		}
+	}
+	sort.Slice(candidateIDs, func(i, j int) bool {
+		return textScores[candidateIDs[i]] > textScores[candidateIDs[j]]
+	})
+	if len(candidateIDs) > recommendationCandidatePool {
+		candidateIDs = candidateIDs[:recommendationCandidatePool]
+	}
+
+	// Jobs that share no terms can still match on company or location
+	var conditions []string
+	var args []interface{}
+	if len(interestedCompanies) > 0 {
+		companyIDs := make([]uuid.UUID, 0, len(interestedCompanies))
+		for id := range interestedCompanies {
+			companyIDs = append(companyIDs, id)
+		}
+		conditions = append(conditions, "organization_id IN ?")
+		args = append(args, companyIDs)
+	}
+	for _, location := range profile.PreferredLocations {
+		if location = strings.TrimSpace(location); location != "" {
+			conditions = append(conditions, "location ILIKE ?")
+			args = append(args, "%"+location+"%")
+		}
+	}
+	if len(conditions) > 0 {
+		var ids []uuid.UUID
+		query := models.PublishedJobs(config.DB.Model(&models.Job{})).
+			Where(strings.Join(conditions, " OR "), args...)
+		if len(appliedJobIDs) > 0 {
+			query = query.Where("id NOT IN ?", appliedJobIDs)
+		}
+		if err := query.Order("created_at DESC").Limit(recommendationCandidatePool).Pluck("id", &ids).Error; err != nil {
+			fail(err)
+			return
+		}
+		candidateIDs = uniqueIDs(append(candidateIDs, ids...))
+	}
+
+	var candidates []models.Job
+	if len(candidateIDs) > 0 {
+		if err := models.PublishedJobs(config.DB).Where("id IN ?", candidateIDs).Find(&candidates).Error; err != nil {
+			fail(err)
+			return
+		}
+	}
+
+	companyNames := make(map[uuid.UUID]string)
+	if len(interestedCompanies) > 0 {
+		var companies []models.User
+		ids := make([]uuid.UUID, 0, len(interestedCompanies))
+		for id := range interestedCompanies {
+			ids = append(ids, id)
+		}
+		if err := config.DB.Select("id", "name").Where("id IN ?", ids).Find(&companies).Error; err != nil {
+			fail(err)
+			return
+		}
+		for _, company := range companies {
+			companyNames[company.ID] = company.Name
+		}
+	}
+
+	skills := utils.NormalizeSkills(profile.Skills)
+	recommendations := []JobRecommendation{}
+	for _, job := range candidates {
+		if applied[job.ID] {
+			continue
+		}
+
+		var score float64
+		var reasons []string
+
+		if textScores[job.ID] > 0 {
+			score += textWeight * textScores[job.ID] / maxTextScore
+			terms := sharedTerms[job.ID]
+			sort.Slice(terms, func(i, j int) bool {
+				return queryWeights[terms[i]] > queryWeights[terms[j]]
+			})
+			reasons = append(reasons, fmt.Sprintf("Matches your profile and activity on: %s", strings.Join(firstN(terms, 3), ", ")))
+		}
+
+		if matched := matchSkills(skills, job); len(matched) > 0 {
+			score += skillWeight * float64(len(matched)) / float64(len(skills))
+			reasons = append(reasons, fmt.Sprintf("Requires skills you have: %s", strings.Join(matched, ", ")))
+		}
+
+		if location := matchLocation(profile.PreferredLocations, job.Location); location != "" {
+			score += locationWeight
+			reasons = append(reasons, fmt.Sprintf("Located in your preferred location: %s", location))
+		}
+
+		if interestedCompanies[job.OrganizationID] {
+			score += companyWeight
+			reasons = append(reasons, fmt.Sprintf("Posted by %s, a company you applied to or saved jobs from", companyNames[job.OrganizationID]))
+		}
+
+		if score == 0 {
+			continue
+		}
+
+		recommendations = append(recommendations, JobRecommendation{
+			Job:     job,
+			Score:   math.Round(score*1000) / 1000,
+			Reasons: reasons,
+		})
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+
+	total := len(recommendations)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Success:    true,
+		Message:    "Recommended jobs retrieved successfully",
+		Object:     recommendations[start:end],
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  int64(total),
+	})
+}
+
+// weighQueryTerms keeps the limit most distinctive terms.
+func weighQueryTerms(weights map[string]float64, limit int) (map[string]float64, map[string]float64, error) {
+	terms := make([]string, 0, len(weights))
+	for term := range weights {
+		terms = append(terms, term)
+	}
+	if len(terms) == 0 {
+		return map[string]float64{}, map[string]float64{}, nil
+	}
+
+	var totalJobs int64
+	if err := config.DB.Model(&models.Job{}).Count(&totalJobs).Error; err != nil {
+		return nil, nil, err
+	}
+	type termCount struct {
+		Term  string
+		Count int64
+	}
+	var counts []termCount
+	if err := config.DB.Model(&models.JobTerm{}).
+		Select("term, COUNT(*) AS count").
+		Where("term IN ?", terms).
+		Group("term").
+		Scan(&counts).Error; err != nil {
+		return nil, nil, err
+	}
+	idf := make(map[string]float64, len(terms))
+	for _, term := range terms {
+		idf[term] = math.Log(float64(totalJobs+1)) + 1
+	}
+	for _, tc := range counts {
+		idf[tc.Term] = math.Log(float64(totalJobs+1)/float64(tc.Count+1)) + 1
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		return weights[terms[i]]*idf[terms[i]] > weights[terms[j]]*idf[terms[j]]
+	})
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	queryWeights := make(map[string]float64, len(terms))
+	for _, term := range terms {
+		queryWeights[term] = weights[term] * idf[term]
+	}
+	return queryWeights, idf, nil
+}
+
+func matchJobTerms(queryWeights, idf map[string]float64, exclude []uuid.UUID) (map[uuid.UUID]float64, map[uuid.UUID][]string, error) {
+	scores := make(map[uuid.UUID]float64)
+	shared := make(map[uuid.UUID][]string)
+	if len(queryWeights) == 0 {
+		return scores, shared, nil
+	}
+
+	terms := make([]string, 0, len(queryWeights))
+	for term := range queryWeights {
+		terms = append(terms, term)
+	}
+	query := models.PublishedJobs(config.DB.Table("job_terms")).
+		Select("job_terms.job_id, job_terms.term, job_terms.weight").
+		Joins("JOIN jobs ON jobs.id = job_terms.job_id").
+		Where("job_terms.term IN ?", terms)
+	if len(exclude) > 0 {
+		query = query.Where("job_terms.job_id NOT IN ?", exclude)
+	}
+	var matches []termMatch
+	if err := query.Scan(&matches).Error; err != nil {
+		return nil, nil, err
+	}
+
+	for _, match := range matches {
+		scores[match.JobID] += queryWeights[match.Term] * match.Weight * idf[match.Term]
+		shared[match.JobID] = append(shared[match.JobID], match.Term)
+	}
+	return scores, shared, nil
+}
+
+func matchSkills(skills []string, job models.Job) []string {
+	jobSkills := make(map[string]bool)
+	for _, skill := range utils.NormalizeSkills(job.Skills) {
+		jobSkills[skill] = true
+	}
+	text := " " + strings.ToLower(job.Title+" "+job.Description) + " "
+
+	var matched []string
+	for _, skill := range skills {
+		if jobSkills[skill] || strings.Contains(text, " "+skill+" ") {
+			matched = append(matched, skill)
+		}
+	}
+	return matched
+}
+
+func matchLocation(preferred []string, location string) string {
+	location = strings.ToLower(location)
+	if location == "" {
+		return ""
+	}
+	for _, pref := range preferred {
+		if p := strings.ToLower(strings.TrimSpace(pref)); p != "" && strings.Contains(location, p) {
+			return pref
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"job-api/config"
+	"job-api/models"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const jobReminderInterval = time.Hour
+
+func jobClosingSoonWindow() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("JOB_CLOSING_SOON_DAYS"))
+	if err != nil || days < 1 {
+		days = 3
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func StartJobClosingReminders() {
+	go func() {
+		for {
+			if err := sendJobClosingReminders(time.Now()); err != nil {
+				log.Println("Failed to send job closing reminders:", err)
+			}
+			time.Sleep(jobReminderInterval)
+		}
+	}()
+}
+
+// Owners are reminded once per closing date.
+func sendJobClosingReminders(now time.Time) error {
+	var jobs []models.Job
+	if err := config.DB.Where("status = ? AND closing_reminder_sent_at IS NULL AND valid_through > ? AND valid_through <= ?",
+		models.JobStatusOpen, now, now.Add(jobClosingSoonWindow())).Find(&jobs).Error; err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			// Claim the reminder so concurrent instances send it only once
+			result := tx.Model(&models.Job{}).Where("id = ? AND closing_reminder_sent_at IS NULL", job.ID).
+				UpdateColumn("closing_reminder_sent_at", now)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			return createNotifications(tx, []models.Notification{{
+				UserID: job.CreatedBy,
+				Type:   models.NotificationJobClosingSoon,
+				Title:  job.Title + " closes soon",
+				Body:   job.Title + " stops accepting applications on " + formatEmailTime(*job.ValidThrough),
+				Data:   map[string]string{"job_id": job.ID.String()},
+			}})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const resumeParseTimeout = 20 * time.Second
+
+// Parse failures are recorded rather than failing the upload.
+func parseUploadedResume(ctx context.Context, file models.File, data []byte) models.ParsedResume {
+	ctx, cancel := context.WithTimeout(ctx, resumeParseTimeout)
+	defer cancel()
+
+	parsed := models.ParsedResume{
+		FileID:  file.ID,
+		OwnerID: file.OwnerID,
+		Parser:  config.ResumeParser.Name(),
+		Status:  models.ResumeParsed,
+	}
+
+	result, err := config.ResumeParser.Parse(ctx, data, file.ContentType)
+	if err != nil {
+		parsed.Status = models.ResumeParseFailed
+		parsed.Error = err.Error()
+	} else {
+		parsed.Text = result.Text
+		parsed.Contact = result.Contact
+		parsed.Skills = result.Skills
+		parsed.Employers = result.Employers
+		parsed.Education = result.Education
+		parsed.YearsExperience = result.YearsExperience
+	}
+
+	if err := config.DB.Create(&parsed).Error; err != nil {
+		log.Println("Failed to save parsed resume:", err)
+		return parsed
+	}
+
+	if parsed.Status == models.ResumeParsed {
+		prefillProfile(parsed)
+	}
+	return parsed
+}
+
+// Anything the applicant already entered is left alone.
+func prefillProfile(parsed models.ParsedResume) {
+	var profile models.ApplicantProfile
+	if err := config.DB.Where("user_id = ?", parsed.OwnerID).First(&profile).Error; err != nil {
+		profile = models.ApplicantProfile{UserID: parsed.OwnerID}
+	}
+
+	changed := false
+	if len(profile.Skills) == 0 && len(parsed.Skills) > 0 {
+		profile.Skills = utils.NormalizeSkills(parsed.Skills)
+		changed = true
+	}
+	if profile.Headline == "" && len(parsed.Employers) > 0 && parsed.Employers[0].Title != "" {
+		profile.Headline = parsed.Employers[0].Title
+		changed = true
+	}
+
+	if changed {
+		if err := config.DB.Save(&profile).Error; err != nil {
+			log.Println("Failed to prefill profile from resume:", err)
+		}
+	}
+}
+
+func GetParsedResume(c *gin.Context) {
+	fileUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid file ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	var file models.File
+	if err := config.DB.First(&file, fileUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "File not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if !canAccessFile(currentUserID, file) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized access",
+			Object:  nil,
+		})
+		return
+	}
+
+	var parsed models.ParsedResume
+	if err := config.DB.Where("file_id = ?", file.ID).First(&parsed).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Parsed resume not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	// The hiring team of a blind reviewed job gets a redacted rendition
+	if file.OwnerID != currentUserID && parsed.ApplicationID != nil {
+		var application models.Application
+		if err := config.DB.Preload("Applicant").Preload("Job").First(&application, *parsed.ApplicationID).Error; err != nil ||
+			applicationHidden(application) {
+			redactParsedResume(&parsed, utils.NameTerms(application.Applicant.Name))
+		}
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Parsed resume retrieved successfully",
+		Object:  parsed,
+	})
+}
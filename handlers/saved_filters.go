@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Filters is a query string such as "status=Interview&tag=senior&min_rating=4".
+type SavedFilterRequest struct {
+	Name  string     `json:"name" validate:"required,min=1,max=100"`
+	JobID *uuid.UUID `json:"job_id"`
+	Query string     `json:"query" validate:"max=2000"`
+}
+
+var errSavedFilterNotFound = errors.New("saved filter not found")
+
+func GetSavedFilters(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	query := config.DB.Where("user_id = ?", userID.(uuid.UUID))
+	if jobID := c.Query("job_id"); jobID != "" {
+		jobUUID, err := uuid.Parse(jobID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.BaseResponse{
+				Success: false,
+				Message: "Invalid job ID",
+				Object:  nil,
+			})
+			return
+		}
+		query = query.Where("job_id IS NULL OR job_id = ?", jobUUID)
+	}
+
+	var filters []models.SavedApplicationFilter
+	if err := query.Order("name ASC").Find(&filters).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch saved filters",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Saved filters retrieved successfully",
+		Object:  filters,
+	})
+}
+
+func CreateSavedFilter(c *gin.Context) {
+	var req SavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	query, errs := validateSavedFilter(req, currentUserID)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+
+	filter := models.SavedApplicationFilter{
+		UserID: currentUserID,
+		JobID:  req.JobID,
+		Name:   strings.TrimSpace(req.Name),
+		Query:  query,
+	}
+	if err := config.DB.Create(&filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to save filter",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Filter saved successfully",
+		Object:  filter,
+	})
+}
+
+func UpdateSavedFilter(c *gin.Context) {
+	filterUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid saved filter ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req SavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	filter, err := findSavedFilter(filterUUID, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Saved filter not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	query, errs := validateSavedFilter(req, currentUserID)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+
+	filter.Name = strings.TrimSpace(req.Name)
+	filter.JobID = req.JobID
+	filter.Query = query
+	if err := config.DB.Save(&filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update saved filter",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Saved filter updated successfully",
+		Object:  filter,
+	})
+}
+
+func DeleteSavedFilter(c *gin.Context) {
+	filterUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid saved filter ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	filter, err := findSavedFilter(filterUUID, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Saved filter not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if err := config.DB.Delete(&filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete saved filter",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Saved filter deleted successfully",
+		Object:  nil,
+	})
+}
+
+func validateSavedFilter(req SavedFilterRequest, userID uuid.UUID) (string, []string) {
+	if err := utils.ValidateStruct(req); err != nil {
+		return "", []string{err.Error()}
+	}
+
+	if req.JobID != nil {
+		var job models.Job
+		if err := config.DB.First(&job, *req.JobID).Error; err != nil {
+			return "", []string{"job not found"}
+		}
+		if !canManageJob(userID, job) {
+			return "", []string{"not on the hiring team for this job"}
+		}
+	}
+
+	params, err := url.ParseQuery(strings.TrimPrefix(strings.TrimSpace(req.Query), "?"))
+	if err != nil {
+		return "", []string{"query must be a URL query string"}
+	}
+
+	var errs []string
+	for key := range params {
+		if !applicationFilterKeys[key] && !strings.HasPrefix(key, "answer[") {
+			errs = append(errs, key+" is not an application filter")
+		}
+	}
+	if _, parseErrs := parseApplicationFilters(params); len(parseErrs) > 0 {
+		errs = append(errs, parseErrs...)
+	}
+	if len(errs) > 0 {
+		return "", errs
+	}
+	return params.Encode(), nil
+}
+
+func findSavedFilter(filterID, userID uuid.UUID) (models.SavedApplicationFilter, error) {
+	var filter models.SavedApplicationFilter
+	if err := config.DB.Where("id = ? AND user_id = ?", filterID, userID).First(&filter).Error; err != nil {
+		return filter, errSavedFilterNotFound
+	}
+	return filter, nil
+}
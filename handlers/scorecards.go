@@ -0,0 +1,724 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type ScorecardCriterionRequest struct {
+	ID          *uuid.UUID           `json:"id"`
+	Name        string               `json:"name" validate:"required,min=1,max=100"`
+	Description string               `json:"description" validate:"max=500"`
+	Type        models.CriterionType `json:"type" validate:"required,oneof=rating text"`
+	Scale       int                  `json:"scale" validate:"omitempty,min=2,max=10"`
+	Required    bool                 `json:"required"`
+}
+
+type ScorecardTemplateRequest struct {
+	Name     string                      `json:"name" validate:"required,min=1,max=100"`
+	Criteria []ScorecardCriterionRequest `json:"criteria" validate:"required,min=1,max=30,dive"`
+}
+
+type ScorecardRequest struct {
+	TemplateID     uuid.UUID                `json:"template_id" validate:"required"`
+	Ratings        []models.ScorecardRating `json:"ratings" validate:"max=30"`
+	Recommendation models.Recommendation    `json:"recommendation" validate:"required,oneof=strong_no no yes strong_yes"`
+	Summary        string                   `json:"summary" validate:"max=5000"`
+}
+
+var errJobNotFound = errors.New("job not found")
+
+func CreateScorecardTemplate(c *gin.Context) {
+	jobUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req ScorecardTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	job, err := findTeamJob(jobUUID, currentUserID)
+	if err != nil {
+		respondJobAccessError(c, err)
+		return
+	}
+
+	template := models.ScorecardTemplate{
+		JobID:     job.ID,
+		Name:      strings.TrimSpace(req.Name),
+		Criteria:  scorecardCriteria(req.Criteria),
+		CreatedBy: currentUserID,
+	}
+	if err := template.ValidateCriteria(); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := config.DB.Create(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create scorecard template",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Scorecard template created successfully",
+		Object:  template,
+	})
+}
+
+func GetScorecardTemplates(c *gin.Context) {
+	jobUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	job, err := findTeamJob(jobUUID, userID.(uuid.UUID))
+	if err != nil {
+		respondJobAccessError(c, err)
+		return
+	}
+
+	var templates []models.ScorecardTemplate
+	if err := config.DB.Where("job_id = ?", job.ID).Order("created_at ASC").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch scorecard templates",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Scorecard templates retrieved successfully",
+		Object:  templates,
+	})
+}
+
+// Templates that interviewers have already used cannot be changed.
+func UpdateScorecardTemplate(c *gin.Context) {
+	jobUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	templateUUID, err := uuid.Parse(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid template ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req ScorecardTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	job, err := findTeamJob(jobUUID, userID.(uuid.UUID))
+	if err != nil {
+		respondJobAccessError(c, err)
+		return
+	}
+
+	var template models.ScorecardTemplate
+	if err := config.DB.Where("id = ? AND job_id = ?", templateUUID, job.ID).First(&template).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Scorecard template not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	var used int64
+	config.DB.Model(&models.Scorecard{}).Where("template_id = ?", template.ID).Count(&used)
+	if used > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Scorecard template is in use",
+			Object:  nil,
+			Errors:  []string{"templates with submitted scorecards cannot be changed; create a new template instead"},
+		})
+		return
+	}
+
+	template.Name = strings.TrimSpace(req.Name)
+	template.Criteria = scorecardCriteria(req.Criteria)
+	if err := template.ValidateCriteria(); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := config.DB.Save(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update scorecard template",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Scorecard template updated successfully",
+		Object:  template,
+	})
+}
+
+func DeleteScorecardTemplate(c *gin.Context) {
+	jobUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	templateUUID, err := uuid.Parse(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid template ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	job, err := findTeamJob(jobUUID, userID.(uuid.UUID))
+	if err != nil {
+		respondJobAccessError(c, err)
+		return
+	}
+
+	var used int64
+	config.DB.Model(&models.Scorecard{}).Where("template_id = ?", templateUUID).Count(&used)
+	if used > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Scorecard template is in use",
+			Object:  nil,
+		})
+		return
+	}
+
+	result := config.DB.Where("id = ? AND job_id = ?", templateUUID, job.ID).Delete(&models.ScorecardTemplate{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete scorecard template",
+			Object:  nil,
+			Errors:  []string{result.Error.Error()},
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Scorecard template not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Scorecard template deleted successfully",
+		Object:  nil,
+	})
+}
+
+func SubmitScorecard(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req ScorecardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	interviewerID := userID.(uuid.UUID)
+
+	application, err := findTeamApplication(appUUID, interviewerID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var template models.ScorecardTemplate
+	if err := config.DB.Where("id = ? AND job_id = ?", req.TemplateID, application.JobID).First(&template).Error; err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid scorecard template",
+			Object:  nil,
+			Errors:  []string{"template does not belong to this job"},
+		})
+		return
+	}
+
+	overall, err := template.Score(req.Ratings)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	var existing int64
+	config.DB.Model(&models.Scorecard{}).
+		Where("application_id = ? AND template_id = ? AND interviewer_id = ?", application.ID, template.ID, interviewerID).
+		Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "You have already submitted this scorecard",
+			Object:  nil,
+		})
+		return
+	}
+
+	scorecard := models.Scorecard{
+		ApplicationID:  application.ID,
+		TemplateID:     template.ID,
+		InterviewerID:  interviewerID,
+		Ratings:        req.Ratings,
+		OverallRating:  overall,
+		Recommendation: req.Recommendation,
+		Summary:        strings.TrimSpace(req.Summary),
+		SubmittedAt:    time.Now(),
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&scorecard).Error; err != nil {
+			return err
+		}
+		return refreshApplicationRating(tx, application.ID)
+	})
+	if isUniqueViolation(err) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "You have already submitted this scorecard",
+			Object:  nil,
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to submit scorecard",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Scorecard submitted successfully",
+		Object:  scorecard,
+	})
+}
+
+func UpdateScorecard(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	scorecardUUID, err := uuid.Parse(c.Param("scorecard_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid scorecard ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req ScorecardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	interviewerID := userID.(uuid.UUID)
+
+	application, err := findTeamApplication(appUUID, interviewerID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var scorecard models.Scorecard
+	if err := config.DB.Where("id = ? AND application_id = ?", scorecardUUID, application.ID).First(&scorecard).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Scorecard not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if scorecard.InterviewerID != interviewerID {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Only the interviewer can edit a scorecard",
+			Object:  nil,
+		})
+		return
+	}
+
+	if req.TemplateID != scorecard.TemplateID {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{"the template of a scorecard cannot be changed"},
+		})
+		return
+	}
+
+	var template models.ScorecardTemplate
+	if err := config.DB.First(&template, scorecard.TemplateID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to load scorecard template",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	overall, err := template.Score(req.Ratings)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	scorecard.Ratings = req.Ratings
+	scorecard.OverallRating = overall
+	scorecard.Recommendation = req.Recommendation
+	scorecard.Summary = strings.TrimSpace(req.Summary)
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&scorecard).Error; err != nil {
+			return err
+		}
+		return refreshApplicationRating(tx, application.ID)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update scorecard",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Scorecard updated successfully",
+		Object:  scorecard,
+	})
+}
+
+// Until team members submit their own scorecard they only see how many were
+// submitted, so earlier opinions cannot sway theirs.
+func GetApplicationScorecards(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	application, err := findTeamApplication(appUUID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	var scorecards []models.Scorecard
+	if err := config.DB.Preload("Interviewer").Where("application_id = ?", application.ID).
+		Order("submitted_at ASC").Find(&scorecards).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch scorecards",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	visible := application.Job.CreatedBy == currentUserID
+	for _, scorecard := range scorecards {
+		if scorecard.InterviewerID == currentUserID {
+			visible = true
+		}
+	}
+
+	if !visible {
+		c.JSON(http.StatusOK, models.BaseResponse{
+			Success: true,
+			Message: "Submit your own scorecard to see other scorecards",
+			Object: gin.H{
+				"submitted_count": len(scorecards),
+				"scorecards":      []models.Scorecard{},
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Scorecards retrieved successfully",
+		Object: gin.H{
+			"submitted_count": len(scorecards),
+			"average_rating":  application.AverageRating,
+			"scorecards":      scorecards,
+		},
+	})
+}
+
+func scoredApplications(userID uuid.UUID, applicationIDs []uuid.UUID) map[uuid.UUID]bool {
+	scored := make(map[uuid.UUID]bool)
+	if len(applicationIDs) == 0 {
+		return scored
+	}
+	var ids []uuid.UUID
+	config.DB.Model(&models.Scorecard{}).Where("interviewer_id = ? AND application_id IN ?", userID, applicationIDs).
+		Distinct().Pluck("application_id", &ids)
+	for _, id := range ids {
+		scored[id] = true
+	}
+	return scored
+}
+
+// canSeeRatings needs the application's Job loaded.
+func canSeeRatings(userID uuid.UUID, application models.Application) bool {
+	return application.Job.CreatedBy == userID || scoredApplications(userID, []uuid.UUID{application.ID})[application.ID]
+}
+
+// The application must not be saved afterwards.
+func hideRatings(application *models.Application) {
+	application.AverageRating = nil
+	application.ScorecardCount = 0
+}
+
+// isUniqueViolation reports whether err comes from a unique index.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func scorecardCriteria(reqs []ScorecardCriterionRequest) []models.ScorecardCriterion {
+	criteria := make([]models.ScorecardCriterion, len(reqs))
+	for i, req := range reqs {
+		criteria[i] = models.ScorecardCriterion{
+			Name:        req.Name,
+			Description: strings.TrimSpace(req.Description),
+			Type:        req.Type,
+			Scale:       req.Scale,
+			Required:    req.Required,
+		}
+		if req.ID != nil {
+			criteria[i].ID = *req.ID
+		}
+	}
+	return criteria
+}
+
+func refreshApplicationRating(tx *gorm.DB, applicationID uuid.UUID) error {
+	var average sql.NullFloat64
+	var count int64
+	if err := tx.Model(&models.Scorecard{}).
+		Select("AVG(overall_rating), COUNT(*)").
+		Where("application_id = ?", applicationID).
+		Row().Scan(&average, &count); err != nil {
+		return err
+	}
+
+	var rating *float64
+	if average.Valid {
+		rounded := math.Round(average.Float64*100) / 100
+		rating = &rounded
+	}
+	return tx.Model(&models.Application{}).Where("id = ?", applicationID).
+		Updates(map[string]interface{}{"average_rating": rating, "scorecard_count": count}).Error
+}
+
+// findTeamJob loads a job for a member of its hiring team.
+func findTeamJob(jobID, userID uuid.UUID) (models.Job, error) {
+	var job models.Job
+	if err := config.DB.First(&job, jobID).Error; err != nil {
+		return job, errJobNotFound
+	}
+	if !canManageJob(userID, job) {
+		return job, errNotHiringTeam
+	}
+	return job, nil
+}
+
+func respondJobAccessError(c *gin.Context, err error) {
+	if errors.Is(err, errNotHiringTeam) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized access",
+			Object:  nil,
+		})
+		return
+	}
+	c.JSON(http.StatusNotFound, models.BaseResponse{
+		Success: false,
+		Message: "Job not found",
+		Object:  nil,
+	})
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ScreeningQuestionRequest struct {
+	Prompt   string               `json:"prompt" validate:"required,min=1,max=300"`
+	Type     models.QuestionType  `json:"type" validate:"required,oneof=yes_no single_choice multi_choice number short_text"`
+	Options  []string             `json:"options" validate:"max=20,dive,min=1,max=100"`
+	Required bool                 `json:"required"`
+	Knockout *models.KnockoutRule `json:"knockout"`
+}
+
+type ScreeningAnswerRequest struct {
+	QuestionID uuid.UUID `json:"question_id" validate:"required"`
+	Value      any       `json:"value"`
+}
+
+func buildScreeningQuestions(jobID uuid.UUID, reqs []ScreeningQuestionRequest) ([]models.ScreeningQuestion, error) {
+	questions := make([]models.ScreeningQuestion, 0, len(reqs))
+	for i, req := range reqs {
+		question := models.ScreeningQuestion{
+			JobID:    jobID,
+			Position: i,
+			Prompt:   strings.TrimSpace(req.Prompt),
+			Type:     req.Type,
+			Options:  req.Options,
+			Required: req.Required,
+			Knockout: req.Knockout,
+		}
+		if err := question.Validate(); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+func loadScreeningQuestions(jobID uuid.UUID) ([]models.ScreeningQuestion, error) {
+	var questions []models.ScreeningQuestion
+	err := config.DB.Where("job_id = ?", jobID).Order("position ASC").Find(&questions).Error
+	return questions, err
+}
+
+// evaluateScreeningAnswers also returns the prompts of failed knockout
+// questions.
+func evaluateScreeningAnswers(questions []models.ScreeningQuestion, reqs []ScreeningAnswerRequest) ([]models.ScreeningAnswer, []string, error) {
+	given := make(map[uuid.UUID]any, len(reqs))
+	for _, req := range reqs {
+		if _, ok := given[req.QuestionID]; ok {
+			return nil, nil, fmt.Errorf("question %s was answered more than once", req.QuestionID)
+		}
+		given[req.QuestionID] = req.Value
+	}
+
+	var answers []models.ScreeningAnswer
+	var failed []string
+	for _, question := range questions {
+		value, answered := given[question.ID]
+		delete(given, question.ID)
+		if !answered || value == nil {
+			if question.Required {
+				return nil, nil, fmt.Errorf("question %q requires an answer", question.Prompt)
+			}
+			continue
+		}
+
+		normalized, err := question.NormalizeAnswer(value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		passed := question.PassesKnockout(normalized)
+		if !passed {
+			failed = append(failed, question.Prompt)
+		}
+		answers = append(answers, models.ScreeningAnswer{
+			QuestionID: question.ID,
+			Prompt:     question.Prompt,
+			Value:      normalized,
+			KnockedOut: !passed,
+		})
+	}
+
+	for questionID := range given {
+		return nil, nil, fmt.Errorf("question %s does not belong to this job", questionID)
+	}
+	return answers, failed, nil
+}
+
+func hideKnockoutRules(questions []models.ScreeningQuestion) {
+	for i := range questions {
+		questions[i].Knockout = nil
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"job-api/config"
+	"job-api/models"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestApplyForJobKnockout(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name       string
+		answer     bool
+		wantStatus models.ApplicationStatus
+	}{
+		{"passing answer", true, models.StatusApplied},
+		{"failing answer", false, models.StatusRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestDB(t)
+			company := createTestUser(t, "Acme", models.RoleCompany)
+			applicant := createTestUser(t, "Jane", models.RoleApplicant)
+			job := createTestJob(t, company, models.Job{Questions: []models.ScreeningQuestion{{
+				Prompt:   "Are you authorized to work in the EU?",
+				Type:     models.QuestionYesNo,
+				Required: true,
+				Knockout: &models.KnockoutRule{ExpectedAnswer: &yes},
+			}}})
+
+			recorder := serveTest(t, ApplyForJob, http.MethodPost, "/jobs/:id/apply", "/jobs/"+job.ID.String()+"/apply",
+				applicant, ApplyJobRequest{
+					ResumeLink: "https://example.com/resume.pdf",
+					Answers:    []ScreeningAnswerRequest{{QuestionID: job.Questions[0].ID, Value: tt.answer}},
+				})
+			expectStatus(t, recorder, http.StatusCreated)
+
+			var application models.Application
+			decodeObject(t, recorder, &application)
+			if application.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", application.Status, tt.wantStatus)
+			}
+
+			var answer models.ScreeningAnswer
+			if err := config.DB.Where("application_id = ?", application.ID).First(&answer).Error; err != nil {
+				t.Fatal(err)
+			}
+			if answer.KnockedOut != !tt.answer {
+				t.Errorf("knocked_out = %v, want %v", answer.KnockedOut, !tt.answer)
+			}
+
+			var rejections []models.ApplicationStatusEvent
+			config.DB.Where("application_id = ? AND to_status = ?", application.ID, models.StatusRejected).Find(&rejections)
+			if tt.answer {
+				if len(rejections) != 0 {
+					t.Fatalf("recorded %d rejections, want none", len(rejections))
+				}
+				return
+			}
+			if len(rejections) != 1 {
+				t.Fatalf("recorded %d rejections, want 1", len(rejections))
+			}
+			rejection := rejections[0]
+			if rejection.ActorID != nil {
+				t.Errorf("rejection actor = %v, want none for an automatic rejection", *rejection.ActorID)
+			}
+			if !strings.Contains(rejection.Reason, "Are you authorized to work in the EU?") {
+				t.Errorf("rejection reason %q does not name the failed question", rejection.Reason)
+			}
+		})
+	}
+}
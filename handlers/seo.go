@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const sitemapPageSize = 10000
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name         `xml:"sitemapindex"`
+	XMLNS    string           `xml:"xmlns,attr"`
+	Sitemaps []sitemapPointer `xml:"sitemap"`
+}
+
+type sitemapPointer struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func GetJobPostingJSONLD(c *gin.Context) {
+	jobID := c.Param("id")
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var job models.Job
+	if err := config.DB.Preload("Creator").
+		Scopes(models.PublishedJobs).
+		First(&job, jobUUID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Job not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	body, err := json.Marshal(models.NewJobPostingLD(job, publicJobURL(job.ID)))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to encode structured data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	writeCached(c, job.UpdatedAt, "application/ld+json; charset=utf-8", body)
+}
+
+// Pages are ordered by creation time so a job keeps its page while it stays
+// open.
+func GetSitemap(c *gin.Context) {
+	var total int64
+	if err := config.DB.Model(&models.Job{}).Scopes(models.PublishedJobs).Count(&total).Error; err != nil {
+		c.String(http.StatusInternalServerError, "failed to build sitemap")
+		return
+	}
+
+	pages := int((total + sitemapPageSize - 1) / sitemapPageSize)
+	if pages == 0 {
+		pages = 1
+	}
+
+	pageParam := c.Query("page")
+	if pageParam == "" {
+		writeSitemapIndex(c, pages)
+		return
+	}
+
+	page, err := strconv.Atoi(pageParam)
+	if err != nil || page < 1 || page > pages {
+		c.String(http.StatusNotFound, "sitemap page not found")
+		return
+	}
+
+	var jobs []models.Job
+	if err := config.DB.Select("id", "updated_at").
+		Scopes(models.PublishedJobs).
+		Order("created_at, id").
+		Offset((page - 1) * sitemapPageSize).Limit(sitemapPageSize).
+		Find(&jobs).Error; err != nil {
+		c.String(http.StatusInternalServerError, "failed to build sitemap")
+		return
+	}
+
+	urlSet := sitemapURLSet{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	var lastModified time.Time
+	for _, job := range jobs {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:     publicJobURL(job.ID),
+			LastMod: job.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+		if job.UpdatedAt.After(lastModified) {
+			lastModified = job.UpdatedAt
+		}
+	}
+
+	writeSitemapXML(c, lastModified, urlSet)
+}
+
+func writeSitemapIndex(c *gin.Context, pages int) {
+	index := sitemapIndex{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	var lastModified time.Time
+
+	for page := 1; page <= pages; page++ {
+		var pageLastMod *time.Time
+		config.DB.Raw(
+			"SELECT MAX(updated_at) FROM (?) AS page",
+			config.DB.Model(&models.Job{}).Select("updated_at").
+				Scopes(models.PublishedJobs).
+				Order("created_at, id").
+				Offset((page-1)*sitemapPageSize).Limit(sitemapPageSize),
+		).Scan(&pageLastMod)
+
+		pointer := sitemapPointer{Loc: fmt.Sprintf("%s/sitemap.xml?page=%d", config.PublicBaseURL, page)}
+		if pageLastMod != nil {
+			pointer.LastMod = pageLastMod.UTC().Format(time.RFC3339)
+			if pageLastMod.After(lastModified) {
+				lastModified = *pageLastMod
+			}
+		}
+		index.Sitemaps = append(index.Sitemaps, pointer)
+	}
+
+	writeSitemapXML(c, lastModified, index)
+}
+
+func writeSitemapXML(c *gin.Context, lastModified time.Time, document interface{}) {
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		c.String(http.StatusInternalServerError, "failed to build sitemap")
+		return
+	}
+
+	writeCached(c, lastModified, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+// publicJobURL is the canonical URL of a published job.
+func publicJobURL(jobID uuid.UUID) string {
+	return config.PublicBaseURL + "/public/jobs/" + jobID.String()
+}
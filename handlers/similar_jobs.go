@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"fmt"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	similarQueryTerms    = 20
+	similarCandidatePool = 50
+)
+
+// Weights of the similar jobs score components; they sum to 1.
+const (
+	similarTextWeight     = 0.6
+	similarSkillWeight    = 0.2
+	similarLocationWeight = 0.1
+	similarCompanyWeight  = 0.1
+)
+
+func GetSimilarJobs(c *gin.Context) {
+	jobID := c.Param("id")
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid job ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	userID, _ := c.Get("user_id")
+
+	var job models.Job
+	err = config.DB.First(&job, jobUUID).Error
+	// Drafts are only visible to the company that owns them
+	if err != nil || (job.Status == models.JobStatusDraft && !canManageJob(userID.(uuid.UUID), job)) {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Job not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	var sourceTerms []models.JobTerm
+	config.DB.Where("job_id = ?", job.ID).Find(&sourceTerms)
+	termWeights := make(map[string]float64, len(sourceTerms))
+	for _, term := range sourceTerms {
+		termWeights[term.Term] = term.Weight
+	}
+
+	queryWeights, idf, err := weighQueryTerms(termWeights, similarQueryTerms)
+	var textScores map[uuid.UUID]float64
+	var sharedTerms map[uuid.UUID][]string
+	if err == nil {
+		textScores, sharedTerms, err = matchJobTerms(queryWeights, idf, []uuid.UUID{job.ID})
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch similar jobs",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	candidateIDs := make([]uuid.UUID, 0, len(textScores))
+	var maxTextScore float64
+	for id, score := range textScores {
+		candidateIDs = append(candidateIDs, id)
+		if score > maxTextScore {
+			maxTextScore = score
+		}
+	}
+	sort.Slice(candidateIDs, func(i, j int) bool {
+		return textScores[candidateIDs[i]] > textScores[candidateIDs[j]]
+	})
+	if len(candidateIDs) > similarCandidatePool {
+		candidateIDs = candidateIDs[:similarCandidatePool]
+	}
+
+	var candidates []models.Job
+	if len(candidateIDs) > 0 {
+		if err := models.PublishedJobs(config.DB).Where("id IN ?", candidateIDs).Find(&candidates).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, models.BaseResponse{
+				Success: false,
+				Message: "Failed to fetch similar jobs",
+				Object:  nil,
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+	}
+
+	var company models.User
+	config.DB.Select("name").First(&company, job.OrganizationID)
+
+	sourceSkills := utils.NormalizeSkills(job.Skills)
+	sourceTitle := termSet(job.Title)
+	sourceDescription := termSet(job.Description)
+	var similar []JobRecommendation
+	for _, candidate := range candidates {
+		var reasons []string
+
+		textScore := textScores[candidate.ID] / maxTextScore
+		score := similarTextWeight * textScore
+		terms := sharedTerms[candidate.ID]
+		sort.Slice(terms, func(i, j int) bool {
+			return queryWeights[terms[i]] > queryWeights[terms[j]]
+		})
+		// Terms shared through skills are reported with the skills below
+		title, description := termSet(candidate.Title), termSet(candidate.Description)
+		var inTitle, inDescription []string
+		for _, term := range terms {
+			if sourceTitle[term] && title[term] {
+				inTitle = append(inTitle, term)
+			} else if sourceDescription[term] && description[term] {
+				inDescription = append(inDescription, term)
+			}
+		}
+		if len(inTitle) > 0 {
+			reasons = append(reasons, fmt.Sprintf("Similar title: %s", strings.Join(firstN(inTitle, 3), ", ")))
+		}
+		if len(inDescription) > 0 {
+			reasons = append(reasons, fmt.Sprintf("Similar description: %s", strings.Join(firstN(inDescription, 3), ", ")))
+		}
+
+		if shared := sharedSkills(sourceSkills, candidate.Skills); len(shared) > 0 {
+			score += similarSkillWeight * float64(len(shared)) / float64(len(sourceSkills))
+			reasons = append(reasons, fmt.Sprintf("Shares skills: %s", strings.Join(shared, ", ")))
+		}
+
+		if job.Location != "" && strings.EqualFold(strings.TrimSpace(job.Location), strings.TrimSpace(candidate.Location)) {
+			score += similarLocationWeight
+			reasons = append(reasons, fmt.Sprintf("Same location: %s", candidate.Location))
+		}
+
+		if candidate.OrganizationID == job.OrganizationID {
+			score += similarCompanyWeight
+			reasons = append(reasons, fmt.Sprintf("Also posted by %s", company.Name))
+		}
+
+		similar = append(similar, JobRecommendation{
+			Job:     candidate,
+			Score:   math.Round(score*1000) / 1000,
+			Reasons: reasons,
+		})
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Score > similar[j].Score
+	})
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Similar jobs retrieved successfully",
+		Object:  similar,
+	})
+}
+
+func termSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, term := range utils.Tokenize(text) {
+		set[term] = true
+	}
+	return set
+}
+
+func firstN(terms []string, n int) []string {
+	if len(terms) > n {
+		return terms[:n]
+	}
+	return terms
+}
+
+func sharedSkills(skills []string, other []string) []string {
+	otherSkills := make(map[string]bool)
+	for _, skill := range utils.NormalizeSkills(other) {
+		otherSkills[skill] = true
+	}
+
+	var shared []string
+	for _, skill := range skills {
+		if otherSkills[skill] {
+			shared = append(shared, skill)
+		}
+	}
+	return shared
+}
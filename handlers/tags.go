@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type ApplicationTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=20,dive,min=1,max=50"`
+}
+
+// TagSummary is a tag with the number of applications it is on.
+type TagSummary struct {
+	models.Tag
+	Applications int64 `json:"applications"`
+}
+
+var errTagNotFound = errors.New("tag not found")
+
+func GetTags(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	orgID := organizationID(userID.(uuid.UUID))
+
+	var tags []TagSummary
+	if err := config.DB.Model(&models.Tag{}).
+		Select("tags.*, (SELECT COUNT(*) FROM application_tags WHERE application_tags.tag_id = tags.id) AS applications").
+		Where("organization_id = ?", orgID).Order("LOWER(name) ASC").
+		Scan(&tags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch tags",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Tags retrieved successfully",
+		Object:  tags,
+	})
+}
+
+func CreateTag(c *gin.Context) {
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	orgID := organizationID(currentUserID)
+	name := strings.TrimSpace(req.Name)
+
+	if tagNameTaken(orgID, name, uuid.Nil) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "A tag with this name already exists",
+			Object:  nil,
+		})
+		return
+	}
+
+	tag := models.Tag{OrganizationID: orgID, Name: name, CreatedBy: currentUserID}
+	if err := config.DB.Create(&tag).Error; isUniqueViolation(err) {
+		// Another request took the name since it was checked
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "A tag with this name already exists",
+			Object:  nil,
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create tag",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Tag created successfully",
+		Object:  tag,
+	})
+}
+
+// UpdateTag renames a tag. The applications it is on keep it.
+func UpdateTag(c *gin.Context) {
+	tagUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid tag ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	orgID := organizationID(userID.(uuid.UUID))
+	tag, err := findTag(tagUUID, orgID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Tag not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if tagNameTaken(orgID, name, tag.ID) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "A tag with this name already exists",
+			Object:  nil,
+		})
+		return
+	}
+
+	tag.Name = name
+	if err := config.DB.Save(&tag).Error; isUniqueViolation(err) {
+		// Another request took the name since it was checked
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "A tag with this name already exists",
+			Object:  nil,
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update tag",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Tag updated successfully",
+		Object:  tag,
+	})
+}
+
+// DeleteTag deletes a tag and removes it from every application.
+func DeleteTag(c *gin.Context) {
+	tagUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid tag ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	tag, err := findTag(tagUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Tag not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM application_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tag).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete tag",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Tag deleted successfully",
+		Object:  nil,
+	})
+}
+
+func AddApplicationTags(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req ApplicationTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	application, err := findTeamApplication(appUUID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+	orgID := application.Job.OrganizationID
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		tags, err := findTags(tx, orgID, req.Tags, true, currentUserID)
+		if err != nil {
+			return err
+		}
+		return tagApplication(tx, application.ID, tags)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to tag application",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	respondApplicationTags(c, application.ID, "Application tagged successfully")
+}
+
+func RemoveApplicationTag(c *gin.Context) {
+	appUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid application ID",
+			Object:  nil,
+		})
+		return
+	}
+	tagUUID, err := uuid.Parse(c.Param("tag_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid tag ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	application, err := findTeamApplication(appUUID, userID.(uuid.UUID))
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+
+	if err := config.DB.Exec("DELETE FROM application_tags WHERE application_id = ? AND tag_id = ?",
+		application.ID, tagUUID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to remove tag",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	respondApplicationTags(c, application.ID, "Tag removed successfully")
+}
+
+// tagApplication links tags to an application, skipping any it already has.
+func tagApplication(tx *gorm.DB, applicationID uuid.UUID, tags []models.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	rows := make([]map[string]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, map[string]interface{}{"application_id": applicationID, "tag_id": tag.ID})
+	}
+	return tx.Table("application_tags").Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func respondApplicationTags(c *gin.Context, applicationID uuid.UUID, message string) {
+	var tags []models.Tag
+	if err := config.DB.Joins("JOIN application_tags ON application_tags.tag_id = tags.id").
+		Where("application_tags.application_id = ?", applicationID).
+		Order("LOWER(tags.name) ASC").Find(&tags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch tags",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: message,
+		Object:  tags,
+	})
+}
+
+func findTag(tagID, orgID uuid.UUID) (models.Tag, error) {
+	var tag models.Tag
+	if err := config.DB.Where("id = ? AND organization_id = ?", tagID, orgID).First(&tag).Error; err != nil {
+		return tag, errTagNotFound
+	}
+	return tag, nil
+}
+
+func tagNameTaken(orgID uuid.UUID, name string, exceptID uuid.UUID) bool {
+	var count int64
+	config.DB.Model(&models.Tag{}).
+		Where("organization_id = ? AND LOWER(name) = ? AND id <> ?", orgID, strings.ToLower(name), exceptID).
+		Count(&count)
+	return count > 0
+}
@@ -0,0 +1,710 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AddToTalentPoolRequest struct {
+	ApplicationID uuid.UUID `json:"application_id" validate:"required"`
+	Note          string    `json:"note" validate:"max=2000"`
+}
+
+type TalentPoolNoteRequest struct {
+	Note string `json:"note" validate:"max=2000"`
+}
+
+type TalentPoolInviteRequest struct {
+	JobID   uuid.UUID `json:"job_id" validate:"required"`
+	Message string    `json:"message" validate:"max=2000"`
+}
+
+type TalentPoolSearchResult struct {
+	Applicant          models.User                 `json:"applicant"`
+	Skills             []string                    `json:"skills"`
+	PreferredLocations []string                    `json:"preferred_locations"`
+	Applications       []TalentPoolApplication     `json:"applications"`
+	TalentPool         *models.TalentPoolCandidate `json:"talent_pool"`
+}
+
+type TalentPoolApplication struct {
+	ID        uuid.UUID                `json:"id"`
+	JobID     uuid.UUID                `json:"job_id"`
+	JobTitle  string                   `json:"job_title"`
+	Status    models.ApplicationStatus `json:"status"`
+	AppliedAt time.Time                `json:"applied_at"`
+}
+
+var errTalentPoolCandidateNotFound = errors.New("talent pool candidate not found")
+
+// GetTalentPool lists the organization's talent pool, optionally by status.
+func GetTalentPool(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	userID, _ := c.Get("user_id")
+	query := config.DB.Model(&models.TalentPoolCandidate{}).
+		Where("organization_id = ?", organizationID(userID.(uuid.UUID)))
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	query.Session(&gorm.Session{}).Count(&total)
+
+	var candidates []models.TalentPoolCandidate
+	if err := query.Preload("Applicant").Preload("Invitations", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at DESC")
+	}).Preload("Invitations.Job").
+		Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&candidates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch talent pool",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Success:    true,
+		Message:    "Talent pool retrieved successfully",
+		Object:     candidates,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  total,
+	})
+}
+
+// Candidates join the pool once they consent.
+func AddToTalentPool(c *gin.Context) {
+	var req AddToTalentPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	application, err := findTeamApplication(req.ApplicationID, currentUserID)
+	if err != nil {
+		respondApplicationAccessError(c, err)
+		return
+	}
+	if applicationHidden(application) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Applicant is hidden by blind review",
+			Object:  nil,
+		})
+		return
+	}
+	orgID := organizationID(currentUserID)
+
+	var existing models.TalentPoolCandidate
+	if err := config.DB.Where("organization_id = ? AND applicant_id = ?", orgID, application.ApplicantID).
+		First(&existing).Error; err == nil {
+		message := "Candidate is already in the talent pool"
+		if existing.Status == models.TalentPoolDeclined {
+			message = "Candidate declined to join the talent pool"
+		}
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: message,
+			Object:  existing,
+		})
+		return
+	}
+
+	var organization models.User
+	if err := config.DB.First(&organization, orgID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to load organization",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	candidate := models.TalentPoolCandidate{
+		OrganizationID:      orgID,
+		ApplicantID:         application.ApplicantID,
+		Status:              models.TalentPoolPending,
+		SourceApplicationID: &application.ID,
+		Note:                strings.TrimSpace(req.Note),
+		AddedBy:             currentUserID,
+	}
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&candidate).Error; err != nil {
+			return err
+		}
+		return createNotifications(tx, []models.Notification{{
+			UserID: application.ApplicantID,
+			Type:   models.NotificationTalentPoolRequest,
+			Title:  "Join " + organization.Name + "'s talent pool?",
+			Body:   organization.Name + " would like to keep your applications on file and invite you to apply for future jobs.",
+			Data:   map[string]string{"talent_pool_id": candidate.ID.String()},
+		}})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to add candidate to talent pool",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Candidate asked to join the talent pool",
+		Object:  candidate,
+	})
+}
+
+func UpdateTalentPoolCandidate(c *gin.Context) {
+	candidateUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid talent pool candidate ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req TalentPoolNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	candidate, err := findTalentPoolCandidate(candidateUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Talent pool candidate not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if err := config.DB.Model(&candidate).Update("note", strings.TrimSpace(req.Note)).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update talent pool candidate",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Talent pool candidate updated successfully",
+		Object:  candidate,
+	})
+}
+
+func RemoveFromTalentPool(c *gin.Context) {
+	candidateUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid talent pool candidate ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	candidate, err := findTalentPoolCandidate(candidateUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Talent pool candidate not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("candidate_id = ?", candidate.ID).Delete(&models.TalentPoolInvitation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&candidate).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to remove candidate from talent pool",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Candidate removed from talent pool",
+		Object:  nil,
+	})
+}
+
+// Applications hidden by blind review are left out of the search.
+func SearchTalentPool(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	orgID := organizationID(currentUserID)
+	managedApplications := func() *gorm.DB {
+		return config.DB.Model(&models.Application{}).Where("job_id IN (?)",
+			organizationJobIDs(orgID)).
+			Where(applicationVisible)
+	}
+	managedResumes := func() *gorm.DB {
+		return config.DB.Model(&models.ParsedResume{}).Select("owner_id").
+			Where("status = ? AND application_id IN (?)", models.ResumeParsed, managedApplications().Select("id"))
+	}
+
+	query := config.DB.Model(&models.User{}).Where("users.id IN (?)", managedApplications().Select("applicant_id"))
+
+	for _, skill := range utils.NormalizeSkills(c.QueryArray("skill")) {
+		encoded, _ := json.Marshal([]string{skill})
+		query = query.Where("(users.id IN (?) OR users.id IN (?))",
+			managedResumes().Where("skills @> ?::jsonb", string(encoded)),
+			config.DB.Model(&models.ApplicantProfile{}).Select("user_id").Where("skills @> ?::jsonb", string(encoded)))
+	}
+	if location := strings.TrimSpace(c.Query("location")); location != "" {
+		query = query.Where("users.id IN (?)", config.DB.Model(&models.ApplicantProfile{}).Select("user_id").
+			Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(preferred_locations) AS location WHERE location ILIKE ?)",
+				"%"+location+"%"))
+	}
+	if stages := nonEmpty(c.QueryArray("stage")); len(stages) > 0 {
+		var lowered []string
+		for _, stage := range stages {
+			lowered = append(lowered, strings.ToLower(stage))
+		}
+		// A stage counts if an application is in it now or ever moved into it
+		query = query.Where("users.id IN (?)", managedApplications().Select("applicant_id").
+			Where("(LOWER(status) IN ? OR id IN (?))", lowered,
+				config.DB.Model(&models.ApplicationStatusEvent{}).Select("application_id").Where("LOWER(to_status) IN ?", lowered)))
+	}
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		query = query.Where("(users.name ILIKE ? OR users.id IN (?))", "%"+q+"%",
+			managedResumes().Where("to_tsvector('english', text) @@ plainto_tsquery('english', ?)", q))
+	}
+	if status := c.Query("pool_status"); status != "" {
+		query = query.Where("users.id IN (?)", config.DB.Model(&models.TalentPoolCandidate{}).Select("applicant_id").
+			Where("organization_id = ? AND status = ?", orgID, status))
+	}
+
+	var total int64
+	query.Session(&gorm.Session{}).Count(&total)
+
+	var applicants []models.User
+	if err := query.Order("users.name ASC").Offset(offset).Limit(pageSize).Find(&applicants).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to search candidates",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	results := make([]TalentPoolSearchResult, len(applicants))
+	index := make(map[uuid.UUID]*TalentPoolSearchResult, len(applicants))
+	ids := make([]uuid.UUID, len(applicants))
+	for i, applicant := range applicants {
+		results[i] = TalentPoolSearchResult{Applicant: applicant, Skills: []string{}, Applications: []TalentPoolApplication{}}
+		index[applicant.ID] = &results[i]
+		ids[i] = applicant.ID
+	}
+
+	if len(ids) > 0 {
+		var applications []models.Application
+		managedApplications().Preload("Job").Where("applicant_id IN ?", ids).
+			Order("applied_at DESC").Find(&applications)
+		for _, application := range applications {
+			result := index[application.ApplicantID]
+			result.Applications = append(result.Applications, TalentPoolApplication{
+				ID:        application.ID,
+				JobID:     application.JobID,
+				JobTitle:  application.Job.Title,
+				Status:    application.Status,
+				AppliedAt: application.AppliedAt,
+			})
+		}
+
+		var profiles []models.ApplicantProfile
+		config.DB.Where("user_id IN ?", ids).Find(&profiles)
+		for _, profile := range profiles {
+			result := index[profile.UserID]
+			result.Skills = append(result.Skills, profile.Skills...)
+			result.PreferredLocations = profile.PreferredLocations
+		}
+
+		var resumes []models.ParsedResume
+		config.DB.Where("owner_id IN ? AND status = ? AND application_id IN (?)", ids, models.ResumeParsed,
+			managedApplications().Select("id")).Find(&resumes)
+		for _, resume := range resumes {
+			result := index[resume.OwnerID]
+			result.Skills = append(result.Skills, resume.Skills...)
+		}
+
+		var candidates []models.TalentPoolCandidate
+		config.DB.Where("organization_id = ? AND applicant_id IN ?", orgID, ids).Find(&candidates)
+		for i := range candidates {
+			index[candidates[i].ApplicantID].TalentPool = &candidates[i]
+		}
+
+		for i := range results {
+			results[i].Skills = utils.NormalizeSkills(results[i].Skills)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Success:    true,
+		Message:    "Candidates retrieved successfully",
+		Object:     results,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  total,
+	})
+}
+
+func InviteTalentPoolCandidate(c *gin.Context) {
+	candidateUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid talent pool candidate ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req TalentPoolInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+	candidate, err := findTalentPoolCandidate(candidateUUID, organizationID(currentUserID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Talent pool candidate not found",
+			Object:  nil,
+		})
+		return
+	}
+	if candidate.Status != models.TalentPoolActive {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Candidate has not consented to the talent pool",
+			Object:  nil,
+		})
+		return
+	}
+
+	var job models.Job
+	if err := config.DB.Preload("Creator").First(&job, req.JobID).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Job not found",
+			Object:  nil,
+		})
+		return
+	}
+	if !canManageJob(currentUserID, job) {
+		c.JSON(http.StatusForbidden, models.BaseResponse{
+			Success: false,
+			Message: "Unauthorized to invite candidates to this job",
+			Object:  nil,
+		})
+		return
+	}
+	if job.Status != models.JobStatusOpen || (job.ValidThrough != nil && job.ValidThrough.Before(time.Now())) {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Job is not open for applications",
+			Object:  nil,
+		})
+		return
+	}
+
+	var count int64
+	config.DB.Model(&models.Application{}).
+		Where("job_id = ? AND applicant_id = ?", job.ID, candidate.ApplicantID).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Candidate has already applied to this job",
+			Object:  nil,
+		})
+		return
+	}
+	config.DB.Model(&models.TalentPoolInvitation{}).
+		Where("job_id = ? AND applicant_id = ?", job.ID, candidate.ApplicantID).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Candidate was already invited to this job",
+			Object:  nil,
+		})
+		return
+	}
+
+	invitation := models.TalentPoolInvitation{
+		CandidateID: candidate.ID,
+		ApplicantID: candidate.ApplicantID,
+		JobID:       job.ID,
+		Message:     strings.TrimSpace(req.Message),
+		InvitedBy:   currentUserID,
+	}
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&invitation).Error; err != nil {
+			return err
+		}
+		if err := createNotifications(tx, []models.Notification{{
+			UserID: candidate.ApplicantID,
+			Type:   models.NotificationJobInvitation,
+			Title:  "Invitation to apply for " + job.Title,
+			Body:   job.Creator.Name + " invited you to apply for " + job.Title,
+			Data: map[string]string{
+				"invitation_id": invitation.ID.String(),
+				"job_id":        job.ID.String(),
+			},
+		}}); err != nil {
+			return err
+		}
+		return queueNotificationEmails(tx, notificationEmail{
+			UserID: candidate.ApplicantID,
+			Type:   models.NotificationJobInvitation,
+			Data: map[string]string{
+				"job_title":    job.Title,
+				"company_name": job.Creator.Name,
+				"location":     job.Location,
+				"message":      invitation.Message,
+				"job_url":      publicJobURL(job.ID),
+			},
+		})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to invite candidate",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	invitation.Job = job
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Candidate invited successfully",
+		Object:  invitation,
+	})
+}
+
+// GetMyTalentPools lists the talent pools the applicant was asked to join.
+func GetMyTalentPools(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var candidates []models.TalentPoolCandidate
+	if err := config.DB.Preload("Organization").Where("applicant_id = ?", userID.(uuid.UUID)).
+		Order("created_at DESC").Find(&candidates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch talent pools",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Recruiter notes are internal to the organization
+	for i := range candidates {
+		candidates[i].Note = ""
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Talent pools retrieved successfully",
+		Object:  candidates,
+	})
+}
+
+// AcceptTalentPool gives the applicant's consent to a talent pool request.
+func AcceptTalentPool(c *gin.Context) {
+	answerTalentPoolRequest(c, true)
+}
+
+// Declining after accepting withdraws the applicant's consent.
+func DeclineTalentPool(c *gin.Context) {
+	answerTalentPoolRequest(c, false)
+}
+
+func answerTalentPoolRequest(c *gin.Context, consent bool) {
+	candidateUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid talent pool ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	var candidate models.TalentPoolCandidate
+	if err := config.DB.Preload("Organization").Where("id = ? AND applicant_id = ?", candidateUUID, userID.(uuid.UUID)).
+		First(&candidate).Error; err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Talent pool request not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{"status": models.TalentPoolDeclined, "declined_at": now}
+	message := "Talent pool declined"
+	if consent {
+		updates = map[string]interface{}{"status": models.TalentPoolActive, "consented_at": now, "declined_at": nil}
+		message = "Joined talent pool"
+	}
+	if err := config.DB.Model(&candidate).Updates(updates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update talent pool consent",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	candidate.Note = ""
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: message,
+		Object:  candidate,
+	})
+}
+
+// GetMyJobInvitations lists the jobs the applicant was invited to apply to.
+func GetMyJobInvitations(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var invitations []models.TalentPoolInvitation
+	if err := config.DB.Preload("Job").Where("applicant_id = ?", userID.(uuid.UUID)).
+		Order("created_at DESC").Find(&invitations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch job invitations",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Job invitations retrieved successfully",
+		Object:  invitations,
+	})
+}
+
+func findTalentPoolCandidate(candidateID, orgID uuid.UUID) (models.TalentPoolCandidate, error) {
+	var candidate models.TalentPoolCandidate
+	if err := config.DB.Preload("Applicant").Where("id = ? AND organization_id = ?", candidateID, orgID).
+		First(&candidate).Error; err != nil {
+		return candidate, errTalentPoolCandidateNotFound
+	}
+	return candidate, nil
+}
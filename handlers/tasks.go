@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"job-api/config"
+	"job-api/queue"
+	"log"
+	"os"
+	"strconv"
+)
+
+// Background task kinds
+const (
+	taskSendEmail       = "email.send"
+	taskDeliverWebhook  = "webhook.deliver"
+	defaultQueueWorkers = 4
+)
+
+// With QUEUE_WORKERS=0 this instance only queues tasks and leaves running
+// them to others, such as `job-api worker`.
+func StartWorkers() {
+	queue.Register(taskSendEmail, queue.DefaultMaxAttempts, sendEmailTask)
+	queue.Register(taskDeliverWebhook, webhookMaxAttempts, deliverWebhookTask)
+	queue.Register(taskBulkApplications, queue.DefaultMaxAttempts, bulkApplicationsTask)
+	queue.Register(taskExpireOffer, queue.DefaultMaxAttempts, expireOfferTask)
+
+	workers, err := strconv.Atoi(os.Getenv("QUEUE_WORKERS"))
+	if err != nil || workers < 0 {
+		workers = defaultQueueWorkers
+	}
+	if workers == 0 {
+		log.Println("Background workers disabled")
+		return
+	}
+	queue.Start(context.Background(), config.DB, workers)
+	log.Printf("Started %d background workers", workers)
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"job-api/config"
+	"job-api/models"
+	"job-api/queue"
+	"job-api/utils"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	webhookMaxAttempts    = 10
+	webhookRequestTimeout = 10 * time.Second
+	webhookResponseLimit  = 2048
+	// Failed attempts in a row before an endpoint is disabled
+	webhookDisableAfter = 20
+)
+
+// webhookClient does not follow redirects and only connects to public
+// addresses, so endpoints cannot reach the server's own network.
+var webhookClient = &http.Client{
+	Timeout: webhookRequestTimeout,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: webhookRequestTimeout,
+			Control: utils.PublicDialControl,
+		}).DialContext,
+		TLSHandshakeTimeout: webhookRequestTimeout,
+		MaxIdleConnsPerHost: 2,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// webhookEvent is the JSON body posted to endpoints.
+type webhookEvent struct {
+	ID        uuid.UUID   `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// webhookTask is the payload of a queued delivery attempt.
+type webhookTask struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+// Called inside a transaction, nothing is sent unless it commits.
+func enqueueWebhooks(tx *gorm.DB, orgID uuid.UUID, eventType string, data interface{}) error {
+	if !models.IsWebhookEventType(eventType) {
+		return nil
+	}
+
+	var endpoints []models.WebhookEndpoint
+	if err := tx.Where("organization_id = ? AND enabled = ?", orgID, true).Find(&endpoints).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	event := webhookEvent{ID: uuid.New(), Type: eventType, CreatedAt: now, Data: data}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribed(eventType) {
+			continue
+		}
+		delivery := models.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			EventID:       event.ID,
+			EventType:     eventType,
+			Payload:       payload,
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+		if err := tx.Create(&delivery).Error; err != nil {
+			return err
+		}
+		if err := queue.Enqueue(tx, taskDeliverWebhook, webhookTask{DeliveryID: delivery.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deliverWebhookTask(ctx context.Context, task models.QueueTask) error {
+	var payload webhookTask
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return queue.Permanent(err)
+	}
+
+	var delivery models.WebhookDelivery
+	if err := config.DB.First(&delivery, payload.DeliveryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	var endpoint models.WebhookEndpoint
+	if err := config.DB.First(&endpoint, delivery.EndpointID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Deliveries to a disabled endpoint wait until it is enabled again
+	if delivery.Status != models.WebhookDeliveryPending || !endpoint.Enabled {
+		return nil
+	}
+
+	delivery.Attempts++
+	started := time.Now()
+	status, body, err := postWebhook(ctx, endpoint, delivery)
+	finished := time.Now()
+
+	attempt := models.WebhookDeliveryAttempt{
+		DeliveryID:     delivery.ID,
+		Attempt:        delivery.Attempts,
+		ResponseStatus: status,
+		ResponseBody:   body,
+		DurationMs:     finished.Sub(started).Milliseconds(),
+	}
+	succeeded := err == nil && status >= 200 && status < 300
+	if err != nil {
+		attempt.Error = err.Error()
+	} else if !succeeded {
+		attempt.Error = "unexpected response status " + strconv.Itoa(status)
+	}
+
+	updates := map[string]interface{}{
+		"attempts":        delivery.Attempts,
+		"response_status": status,
+		"last_error":      attempt.Error,
+	}
+	exhausted := delivery.Attempts >= webhookMaxAttempts
+	switch {
+	case succeeded:
+		updates["status"] = models.WebhookDeliverySucceeded
+		updates["next_attempt_at"] = nil
+	case exhausted:
+		updates["status"] = models.WebhookDeliveryFailed
+		updates["next_attempt_at"] = nil
+	default:
+		updates["next_attempt_at"] = finished.Add(queue.RetryDelay(task.Attempts))
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attempt).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+		return recordWebhookResult(tx, endpoint, succeeded, finished)
+	})
+	switch {
+	case err != nil:
+		return err
+	case succeeded:
+		return nil
+	case exhausted:
+		return queue.Permanent(errors.New(attempt.Error))
+	default:
+		return errors.New(attempt.Error)
+	}
+}
+
+func requeueWebhookDeliveries(tx *gorm.DB, endpointID uuid.UUID) error {
+	var deliveries []models.WebhookDelivery
+	if err := tx.Where("endpoint_id = ? AND status = ?", endpointID, models.WebhookDeliveryPending).
+		Find(&deliveries).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, delivery := range deliveries {
+		if err := tx.Model(&delivery).Update("next_attempt_at", now).Error; err != nil {
+			return err
+		}
+		if err := queue.Enqueue(tx, taskDeliverWebhook, webhookTask{DeliveryID: delivery.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func postWebhook(ctx context.Context, endpoint models.WebhookEndpoint, delivery models.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "job-api-webhooks")
+	req.Header.Set("X-Webhook-Id", delivery.EventID.String())
+	req.Header.Set("X-Webhook-Delivery", delivery.ID.String())
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", utils.SignWebhook(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	return resp.StatusCode, string(body), err
+}
+
+func recordWebhookResult(tx *gorm.DB, endpoint models.WebhookEndpoint, succeeded bool, now time.Time) error {
+	if succeeded {
+		return tx.Model(&models.WebhookEndpoint{}).Where("id = ?", endpoint.ID).
+			UpdateColumns(map[string]interface{}{"consecutive_failures": 0, "last_success_at": now}).Error
+	}
+
+	if err := tx.Model(&models.WebhookEndpoint{}).Where("id = ?", endpoint.ID).
+		UpdateColumn("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error; err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("Disabled after %d failed delivery attempts in a row", webhookDisableAfter)
+	result := tx.Model(&models.WebhookEndpoint{}).
+		Where("id = ? AND enabled = ? AND consecutive_failures >= ?", endpoint.ID, true, webhookDisableAfter).
+		Updates(map[string]interface{}{"enabled": false, "disabled_at": now, "disabled_reason": reason})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	return createNotifications(tx, []models.Notification{{
+		UserID: endpoint.OrganizationID,
+		Type:   models.NotificationWebhookDisabled,
+		Title:  "Webhook endpoint disabled",
+		Body:   endpoint.URL + " was disabled after repeated delivery failures. Pending deliveries resume when it is enabled again.",
+		Data:   map[string]string{"webhook_endpoint_id": endpoint.ID.String()},
+	}})
+}
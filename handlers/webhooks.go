@@ -0,0 +1,548 @@
+package handlers
+
+import (
+	"errors"
+	"job-api/config"
+	"job-api/models"
+	"job-api/queue"
+	"job-api/utils"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2000"`
+	Description string   `json:"description" validate:"max=200"`
+	EventTypes  []string `json:"event_types" validate:"required,min=1,dive,required"`
+}
+
+// Enabling an endpoint clears its failure count.
+type UpdateWebhookEndpointRequest struct {
+	URL         *string  `json:"url" validate:"omitempty,url,max=2000"`
+	Description *string  `json:"description" validate:"omitempty,max=200"`
+	EventTypes  []string `json:"event_types" validate:"omitempty,min=1,dive,required"`
+	Enabled     *bool    `json:"enabled"`
+}
+
+// The secret is only shown when it is created or rotated.
+type WebhookEndpointSecretResponse struct {
+	models.WebhookEndpoint
+	Secret string `json:"secret"`
+}
+
+var errWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+
+func GetWebhookEndpoints(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	orgID := organizationID(userID.(uuid.UUID))
+
+	var endpoints []models.WebhookEndpoint
+	if err := config.DB.Where("organization_id = ?", orgID).Order("created_at ASC").Find(&endpoints).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch webhook endpoints",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Webhook endpoints retrieved successfully",
+		Object:  endpoints,
+	})
+}
+
+func CreateWebhookEndpoint(c *gin.Context) {
+	var req CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if errs := validateWebhookEndpoint(req.URL, req.EventTypes); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+
+	secret, err := utils.GenerateWebhookSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to generate webhook secret",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID := userID.(uuid.UUID)
+
+	endpoint := models.WebhookEndpoint{
+		OrganizationID: organizationID(currentUserID),
+		URL:            strings.TrimSpace(req.URL),
+		Description:    strings.TrimSpace(req.Description),
+		EventTypes:     uniqueStrings(req.EventTypes),
+		Secret:         secret,
+		Enabled:        true,
+		CreatedBy:      currentUserID,
+	}
+
+	if err := config.DB.Create(&endpoint).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to create webhook endpoint",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.BaseResponse{
+		Success: true,
+		Message: "Webhook endpoint created successfully",
+		Object:  WebhookEndpointSecretResponse{WebhookEndpoint: endpoint, Secret: secret},
+	})
+}
+
+func UpdateWebhookEndpoint(c *gin.Context) {
+	endpointUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid webhook endpoint ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	var req UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	endpoint, err := findWebhookEndpoint(endpointUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Webhook endpoint not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	if req.URL != nil {
+		endpoint.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Description != nil {
+		endpoint.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.EventTypes != nil {
+		endpoint.EventTypes = uniqueStrings(req.EventTypes)
+	}
+	reenabled := req.Enabled != nil && *req.Enabled && !endpoint.Enabled
+	if req.Enabled != nil {
+		if reenabled {
+			endpoint.ConsecutiveFailures = 0
+			endpoint.DisabledAt = nil
+			endpoint.DisabledReason = ""
+		}
+		if !*req.Enabled && endpoint.Enabled {
+			now := time.Now()
+			endpoint.DisabledAt = &now
+			endpoint.DisabledReason = "Disabled manually"
+		}
+		endpoint.Enabled = *req.Enabled
+	}
+
+	if errs := validateWebhookEndpoint(endpoint.URL, endpoint.EventTypes); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Validation failed",
+			Object:  nil,
+			Errors:  errs,
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&endpoint).Error; err != nil {
+			return err
+		}
+		if reenabled {
+			return requeueWebhookDeliveries(tx, endpoint.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to update webhook endpoint",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Webhook endpoint updated successfully",
+		Object:  endpoint,
+	})
+}
+
+func DeleteWebhookEndpoint(c *gin.Context) {
+	endpointUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid webhook endpoint ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	endpoint, err := findWebhookEndpoint(endpointUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Webhook endpoint not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		deliveries := tx.Model(&models.WebhookDelivery{}).Select("id").Where("endpoint_id = ?", endpoint.ID)
+		if err := tx.Where("delivery_id IN (?)", deliveries).Delete(&models.WebhookDeliveryAttempt{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("endpoint_id = ?", endpoint.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&endpoint).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to delete webhook endpoint",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Webhook endpoint deleted successfully",
+		Object:  nil,
+	})
+}
+
+// Deliveries still being retried are signed with the new secret.
+func RotateWebhookSecret(c *gin.Context) {
+	endpointUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid webhook endpoint ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	endpoint, err := findWebhookEndpoint(endpointUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Webhook endpoint not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	secret, err := utils.GenerateWebhookSecret()
+	if err == nil {
+		err = config.DB.Model(&endpoint).Update("secret", secret).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to rotate webhook secret",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Webhook secret rotated successfully",
+		Object:  WebhookEndpointSecretResponse{WebhookEndpoint: endpoint, Secret: secret},
+	})
+}
+
+func GetWebhookDeliveries(c *gin.Context) {
+	endpointUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid webhook endpoint ID",
+			Object:  nil,
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	userID, _ := c.Get("user_id")
+	endpoint, err := findWebhookEndpoint(endpointUUID, organizationID(userID.(uuid.UUID)))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Webhook endpoint not found",
+			Object:  nil,
+		})
+		return
+	}
+
+	query := config.DB.Model(&models.WebhookDelivery{}).Where("endpoint_id = ?", endpoint.ID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	query.Session(&gorm.Session{}).Count(&total)
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Order("created_at DESC").
+		Offset(offset).Limit(pageSize).Find(&deliveries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to fetch webhook deliveries",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse{
+		Success:    true,
+		Message:    "Webhook deliveries retrieved successfully",
+		Object:     deliveries,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  total,
+	})
+}
+
+// GetWebhookDelivery shows a delivery with the log of every attempt made.
+func GetWebhookDelivery(c *gin.Context) {
+	delivery, ok := findWebhookDeliveryForRequest(c)
+	if !ok {
+		return
+	}
+
+	config.DB.Where("delivery_id = ?", delivery.ID).Order("attempt ASC").Find(&delivery.AttemptLog)
+
+	c.JSON(http.StatusOK, models.BaseResponse{
+		Success: true,
+		Message: "Webhook delivery retrieved successfully",
+		Object:  delivery,
+	})
+}
+
+func RedeliverWebhook(c *gin.Context) {
+	delivery, ok := findWebhookDeliveryForRequest(c)
+	if !ok {
+		return
+	}
+
+	var endpoint models.WebhookEndpoint
+	config.DB.First(&endpoint, delivery.EndpointID)
+	if !endpoint.Enabled {
+		c.JSON(http.StatusConflict, models.BaseResponse{
+			Success: false,
+			Message: "Enable the webhook endpoint before redelivering",
+			Object:  nil,
+		})
+		return
+	}
+
+	now := time.Now()
+	redelivery := models.WebhookDelivery{
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &delivery.ID,
+	}
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&redelivery).Error; err != nil {
+			return err
+		}
+		return queue.Enqueue(tx, taskDeliverWebhook, webhookTask{DeliveryID: redelivery.ID})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.BaseResponse{
+			Success: false,
+			Message: "Failed to queue webhook redelivery",
+			Object:  nil,
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.BaseResponse{
+		Success: true,
+		Message: "Webhook redelivery queued",
+		Object:  redelivery,
+	})
+}
+
+func findWebhookEndpoint(endpointID, orgID uuid.UUID) (models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := config.DB.Where("id = ? AND organization_id = ?", endpointID, orgID).First(&endpoint).Error; err != nil {
+		return endpoint, errWebhookEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+func findWebhookDeliveryForRequest(c *gin.Context) (models.WebhookDelivery, bool) {
+	var delivery models.WebhookDelivery
+
+	endpointUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid webhook endpoint ID",
+			Object:  nil,
+		})
+		return delivery, false
+	}
+	deliveryUUID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.BaseResponse{
+			Success: false,
+			Message: "Invalid webhook delivery ID",
+			Object:  nil,
+		})
+		return delivery, false
+	}
+
+	userID, _ := c.Get("user_id")
+	endpoint, err := findWebhookEndpoint(endpointUUID, organizationID(userID.(uuid.UUID)))
+	if err == nil {
+		err = config.DB.Where("id = ? AND endpoint_id = ?", deliveryUUID, endpoint.ID).First(&delivery).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.BaseResponse{
+			Success: false,
+			Message: "Webhook delivery not found",
+			Object:  nil,
+		})
+		return delivery, false
+	}
+	return delivery, true
+}
+
+func validateWebhookEndpoint(rawURL string, eventTypes []string) []string {
+	var errs []string
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errs = append(errs, "url must be an http or https URL")
+	} else if !publicWebhookHost(parsed.Hostname()) {
+		errs = append(errs, "url must point to a public address")
+	}
+	for _, eventType := range eventTypes {
+		if !models.IsWebhookEventType(eventType) {
+			errs = append(errs, "unknown webhook event type "+eventType)
+		}
+	}
+	return errs
+}
+
+// Names that resolve to internal addresses are refused when delivering.
+func publicWebhookHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return utils.PublicIP(ip)
+	}
+	return true
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer writes messages to .eml files in Dir, for local development.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileMailer{Dir: dir, From: from}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := Encode(m.From, msg, now)
+	if err != nil {
+		return err
+	}
+
+	random := make([]byte, 4)
+	rand.Read(random)
+	name := now.UTC().Format("20060102T150405.000000000") + "-" + hex.EncodeToString(random) + ".eml"
+	return os.WriteFile(filepath.Join(m.Dir, name), data, 0o640)
+}
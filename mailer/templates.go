@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+)
+
+// DefaultLocale is used when no templates exist for a user's locale.
+const DefaultLocale = "en"
+
+//go:embed templates
+var templateFS embed.FS
+
+// A template named "x" is rendered from its "x.subject" and "x.body".
+var templates = loadTemplates()
+
+func loadTemplates() map[string]*template.Template {
+	sets := make(map[string]*template.Template)
+	locales, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	for _, locale := range locales {
+		if !locale.IsDir() {
+			continue
+		}
+		set := template.New(locale.Name()).Option("missingkey=zero")
+		sets[locale.Name()] = template.Must(set.ParseFS(templateFS, "templates/"+locale.Name()+"/*.tmpl"))
+	}
+	return sets
+}
+
+// HasLocale reports whether templates exist for the locale or its language.
+func HasLocale(locale string) bool {
+	_, ok := resolveLocale(locale)
+	return ok
+}
+
+// resolveLocale falls back to the language and then to DefaultLocale.
+func resolveLocale(locale string) (string, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if _, ok := templates[locale]; ok {
+		return locale, true
+	}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		if _, ok := templates[language]; ok {
+			return language, true
+		}
+	}
+	return DefaultLocale, false
+}
+
+// Render returns the subject and body of the named template in the locale.
+func Render(locale, name string, data map[string]string) (string, string, error) {
+	resolved, _ := resolveLocale(locale)
+	set := templates[resolved]
+	if set.Lookup(name+".subject") == nil {
+		set = templates[DefaultLocale]
+	}
+	if set.Lookup(name+".subject") == nil {
+		return "", "", fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, body bytes.Buffer
+	if err := set.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return "", "", err
+	}
+	if err := set.ExecuteTemplate(&body, name+".body", data); err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(subject.String()), tidyBody(body.String()), nil
+}
+
+// tidyBody drops the blank lines left behind by template actions.
+func tidyBody(body string) string {
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	var out []string
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && len(out) > 0 && out[len(out)-1] == "" {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n") + "\n"
+}
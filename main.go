@@ -3,6 +3,10 @@ package main
 import (
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"job-api/config"
 	"job-api/handlers"
 	"job-api/middleware"
@@ -20,14 +24,53 @@ func main() {
 	// Connect to database
 	config.ConnectDatabase()
 
-	// Setup Gin router
-	r := gin.Default()
+	// Links the API hands out point at PUBLIC_BASE_URL
+	config.LoadPublicBaseURL()
+
+	// Configure outgoing email
+	config.ConnectMailer()
+
+	// Run queued background tasks such as emails and webhooks
+	handlers.StartWorkers()
+
+	// `job-api worker` only runs background tasks
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		log.Println("Worker started")
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+		<-quit
+		return
+	}
+
+	// Configure file storage, which only the API serves
+	config.ConnectStorage()
+
+	// Relay real-time events between instances
+	config.ConnectRealtime()
+
+	// Remind companies about jobs that stop accepting applications soon
+	handlers.StartJobClosingReminders()
+
+	// Setup Gin router, keeping ?access_token= out of the access log
+	r := gin.New()
+	r.Use(middleware.Logger(), gin.Recovery())
+
+	// Only TRUSTED_PROXIES may set the client IP, which rate limits are keyed on
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
 
 	// CORS middleware
 	r.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, If-None-Match, If-Modified-Since")
 		
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -42,6 +85,38 @@ func main() {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// Background queue metrics for Prometheus
+	r.GET("/metrics", handlers.GetMetrics)
+
+	// Public read-only job board
+	publicRateLimit, _ := strconv.Atoi(os.Getenv("PUBLIC_RATE_LIMIT_PER_MINUTE"))
+	if publicRateLimit < 1 {
+		publicRateLimit = 60
+	}
+	publicLimiter := middleware.RateLimit(publicRateLimit, publicRateLimit/2+1)
+	r.GET("/sitemap.xml", publicLimiter, handlers.GetSitemap)
+	public := r.Group("/public")
+	public.Use(publicLimiter)
+	{
+		public.GET("/jobs", handlers.PublicListJobs)
+		public.GET("/jobs/:id", handlers.PublicGetJob)
+		public.GET("/jobs/:id/jsonld", handlers.GetJobPostingJSONLD)
+
+		// Feeds accept the same title, location and company_name filters as browse
+		public.GET("/feeds/jobs.atom", handlers.GetJobsAtomFeed)
+		public.GET("/feeds/jobs.rss", handlers.GetJobsRSSFeed)
+		public.GET("/feeds/jobs.xml", handlers.ExportJobsXML)
+		public.GET("/feeds/companies/:id/jobs.atom", handlers.GetCompanyJobsAtomFeed)
+		public.GET("/feeds/companies/:id/jobs.rss", handlers.GetCompanyJobsRSSFeed)
+	}
+
+	// Signed file downloads; the signature is the authorization
+	r.GET("/files/:id", handlers.DownloadFile)
+
+	// Signed email unsubscribe links. GET only asks for confirmation
+	r.GET("/unsubscribe", publicLimiter, handlers.UnsubscribeConfirmation)
+	r.POST("/unsubscribe", publicLimiter, handlers.Unsubscribe)
+
 	// Auth routes
 	auth := r.Group("/api/auth")
 	{
@@ -61,14 +136,52 @@ func main() {
 			jobs.PUT("/:id", middleware.RequireRole(models.RoleCompany), handlers.UpdateJob)
 			jobs.DELETE("/:id", middleware.RequireRole(models.RoleCompany), handlers.DeleteJob)
 			jobs.GET("/my-jobs", middleware.RequireRole(models.RoleCompany), handlers.GetMyJobs)
+			jobs.GET("/:id/scorecard-templates", middleware.RequireRole(models.RoleCompany), handlers.GetScorecardTemplates)
+			jobs.POST("/:id/scorecard-templates", middleware.RequireRole(models.RoleCompany), handlers.CreateScorecardTemplate)
+			jobs.PUT("/:id/scorecard-templates/:template_id", middleware.RequireRole(models.RoleCompany), handlers.UpdateScorecardTemplate)
+			jobs.DELETE("/:id/scorecard-templates/:template_id", middleware.RequireRole(models.RoleCompany), handlers.DeleteScorecardTemplate)
 			jobs.GET("/:id/applications", middleware.RequireRole(models.RoleCompany), handlers.GetJobApplications)
 
 			// Applicant only routes
 			jobs.GET("", middleware.RequireRole(models.RoleApplicant), handlers.BrowseJobs)
+			jobs.GET("/recommended", middleware.RequireRole(models.RoleApplicant), handlers.GetRecommendedJobs)
+			jobs.GET("/bookmarks", middleware.RequireRole(models.RoleApplicant), handlers.GetMyBookmarks)
 			jobs.POST("/:id/apply", middleware.RequireRole(models.RoleApplicant), handlers.ApplyForJob)
+			jobs.POST("/:id/bookmark", middleware.RequireRole(models.RoleApplicant), handlers.BookmarkJob)
+			jobs.DELETE("/:id/bookmark", middleware.RequireRole(models.RoleApplicant), handlers.RemoveBookmark)
 
 			// Both roles can access
 			jobs.GET("/:id", handlers.GetJobDetails)
+			jobs.GET("/:id/similar", handlers.GetSimilarJobs)
+		}
+
+		// Hiring pipeline routes (Company only)
+		pipelines := api.Group("/pipelines")
+		pipelines.Use(middleware.RequireRole(models.RoleCompany))
+		{
+			pipelines.POST("", handlers.CreatePipeline)
+			pipelines.GET("", handlers.GetPipelines)
+			pipelines.GET("/:id", handlers.GetPipeline)
+			pipelines.PUT("/:id", handlers.UpdatePipeline)
+			pipelines.DELETE("/:id", handlers.DeletePipeline)
+		}
+
+		// File routes
+		files := api.Group("/files")
+		{
+			files.POST("/resumes", middleware.RequireRole(models.RoleApplicant), handlers.UploadResume)
+			files.POST("/attachments", handlers.UploadAttachment)
+			files.GET("/:id/url", handlers.GetFileURL)
+			files.GET("/:id/parsed", handlers.GetParsedResume)
+			files.DELETE("/:id", handlers.DeleteFile)
+		}
+
+		// Profile routes
+		profile := api.Group("/profile")
+		{
+			// Applicant only routes
+			profile.GET("", middleware.RequireRole(models.RoleApplicant), handlers.GetMyProfile)
+			profile.PUT("", middleware.RequireRole(models.RoleApplicant), handlers.UpdateMyProfile)
 		}
 
 		// Application routes
@@ -76,10 +189,154 @@ func main() {
 		{
 			// Applicant only routes
 			applications.GET("/my-applications", middleware.RequireRole(models.RoleApplicant), handlers.GetMyApplications)
+			applications.POST("/:id/withdraw", middleware.RequireRole(models.RoleApplicant), handlers.WithdrawApplication)
 
 			// Company only routes
+			applications.POST("/bulk", middleware.RequireRole(models.RoleCompany), handlers.BulkUpdateApplications)
+			applications.GET("/bulk/:id", middleware.RequireRole(models.RoleCompany), handlers.GetBulkOperation)
 			applications.PUT("/:id/status", middleware.RequireRole(models.RoleCompany), handlers.UpdateApplicationStatus)
+			applications.POST("/:id/reveal", middleware.RequireRole(models.RoleCompany), handlers.RevealApplication)
+			applications.GET("/:id/reveals", middleware.RequireRole(models.RoleCompany), handlers.GetApplicationReveals)
+			applications.POST("/:id/tags", middleware.RequireRole(models.RoleCompany), handlers.AddApplicationTags)
+			applications.DELETE("/:id/tags/:tag_id", middleware.RequireRole(models.RoleCompany), handlers.RemoveApplicationTag)
+			applications.GET("/:id/notes", middleware.RequireRole(models.RoleCompany), handlers.GetApplicationNotes)
+			applications.POST("/:id/notes", middleware.RequireRole(models.RoleCompany), handlers.CreateApplicationNote)
+			applications.PUT("/:id/notes/:note_id", middleware.RequireRole(models.RoleCompany), handlers.UpdateApplicationNote)
+			applications.DELETE("/:id/notes/:note_id", middleware.RequireRole(models.RoleCompany), handlers.DeleteApplicationNote)
+			applications.GET("/:id/scorecards", middleware.RequireRole(models.RoleCompany), handlers.GetApplicationScorecards)
+			applications.POST("/:id/scorecards", middleware.RequireRole(models.RoleCompany), handlers.SubmitScorecard)
+			applications.PUT("/:id/scorecards/:scorecard_id", middleware.RequireRole(models.RoleCompany), handlers.UpdateScorecard)
+			applications.POST("/:id/interviews", middleware.RequireRole(models.RoleCompany), handlers.CreateInterview)
+			applications.POST("/:id/offers", middleware.RequireRole(models.RoleCompany), handlers.CreateOffer)
+
+			// Applicant or owning company
+			applications.GET("/:id", handlers.GetApplication)
+			applications.GET("/:id/history", handlers.GetApplicationHistory)
+			applications.GET("/:id/interviews", handlers.GetApplicationInterviews)
+			applications.GET("/:id/offers", handlers.GetApplicationOffers)
+			applications.GET("/:id/messages", handlers.GetApplicationMessages)
+			applications.POST("/:id/messages", handlers.SendMessage)
+			applications.POST("/:id/messages/read", handlers.MarkMessagesRead)
+		}
+
+		// Interview routes
+		interviews := api.Group("/interviews")
+		{
+			interviews.POST("/:id/select-slot", middleware.RequireRole(models.RoleApplicant), handlers.SelectInterviewSlot)
+			interviews.PUT("/:id", middleware.RequireRole(models.RoleCompany), handlers.UpdateInterview)
+
+			// Applicant or hiring team
+			interviews.POST("/:id/cancel", handlers.CancelInterview)
+			interviews.GET("/:id/invite.ics", handlers.GetInterviewInvite)
+		}
+
+		// Organization routes (Company only)
+		organization := api.Group("/organization")
+		organization.Use(middleware.RequireRole(models.RoleCompany))
+		{
+			organization.GET("/members", handlers.GetOrganizationMembers)
+			organization.POST("/members", handlers.InviteOrganizationMember)
+			organization.DELETE("/members/:user_id", handlers.RemoveOrganizationMember)
+			organization.GET("/invitations", handlers.GetOrganizationInvitations)
+			organization.POST("/invitations/:id/accept", handlers.AcceptOrganizationInvitation)
+			organization.POST("/invitations/:id/decline", handlers.DeclineOrganizationInvitation)
 		}
+
+		// Messaging routes
+		api.GET("/messages/unread", handlers.GetUnreadMessageCounts)
+		messageTemplates := api.Group("/message-templates")
+		messageTemplates.Use(middleware.RequireRole(models.RoleCompany))
+		{
+			messageTemplates.GET("", handlers.GetMessageTemplates)
+			messageTemplates.POST("", handlers.CreateMessageTemplate)
+			messageTemplates.PUT("/:id", handlers.UpdateMessageTemplate)
+			messageTemplates.DELETE("/:id", handlers.DeleteMessageTemplate)
+		}
+
+		// Tag and saved filter routes (Company only)
+		tags := api.Group("/tags")
+		tags.Use(middleware.RequireRole(models.RoleCompany))
+		{
+			tags.GET("", handlers.GetTags)
+			tags.POST("", handlers.CreateTag)
+			tags.PUT("/:id", handlers.UpdateTag)
+			tags.DELETE("/:id", handlers.DeleteTag)
+		}
+		savedFilters := api.Group("/saved-filters")
+		savedFilters.Use(middleware.RequireRole(models.RoleCompany))
+		{
+			savedFilters.GET("", handlers.GetSavedFilters)
+			savedFilters.POST("", handlers.CreateSavedFilter)
+			savedFilters.PUT("/:id", handlers.UpdateSavedFilter)
+			savedFilters.DELETE("/:id", handlers.DeleteSavedFilter)
+		}
+
+		// Talent pool routes
+		talentPool := api.Group("/talent-pool")
+		{
+			// Applicant only routes
+			talentPool.GET("/memberships", middleware.RequireRole(models.RoleApplicant), handlers.GetMyTalentPools)
+			talentPool.POST("/memberships/:id/accept", middleware.RequireRole(models.RoleApplicant), handlers.AcceptTalentPool)
+			talentPool.POST("/memberships/:id/decline", middleware.RequireRole(models.RoleApplicant), handlers.DeclineTalentPool)
+			talentPool.GET("/invitations", middleware.RequireRole(models.RoleApplicant), handlers.GetMyJobInvitations)
+
+			// Company only routes
+			talentPool.GET("", middleware.RequireRole(models.RoleCompany), handlers.GetTalentPool)
+			talentPool.POST("", middleware.RequireRole(models.RoleCompany), handlers.AddToTalentPool)
+			talentPool.GET("/search", middleware.RequireRole(models.RoleCompany), handlers.SearchTalentPool)
+			talentPool.PUT("/:id", middleware.RequireRole(models.RoleCompany), handlers.UpdateTalentPoolCandidate)
+			talentPool.DELETE("/:id", middleware.RequireRole(models.RoleCompany), handlers.RemoveFromTalentPool)
+			talentPool.POST("/:id/invite", middleware.RequireRole(models.RoleCompany), handlers.InviteTalentPoolCandidate)
+		}
+
+		// Offer routes
+		offers := api.Group("/offers")
+		{
+			// Applicant only routes
+			offers.POST("/:id/accept", middleware.RequireRole(models.RoleApplicant), handlers.AcceptOffer)
+			offers.POST("/:id/decline", middleware.RequireRole(models.RoleApplicant), handlers.DeclineOffer)
+
+			// Company only routes
+			offers.PUT("/:id", middleware.RequireRole(models.RoleCompany), handlers.UpdateOffer)
+			offers.POST("/:id/submit", middleware.RequireRole(models.RoleCompany), handlers.SubmitOffer)
+			offers.POST("/:id/approve", middleware.RequireRole(models.RoleCompany), handlers.ApproveOffer)
+			offers.POST("/:id/reject", middleware.RequireRole(models.RoleCompany), handlers.RejectOffer)
+			offers.POST("/:id/send", middleware.RequireRole(models.RoleCompany), handlers.SendOffer)
+			offers.POST("/:id/withdraw", middleware.RequireRole(models.RoleCompany), handlers.WithdrawOffer)
+
+			// Both roles
+			offers.GET("/:id", handlers.GetOffer)
+		}
+
+		// Notification routes
+		api.GET("/notifications", handlers.GetNotifications)
+		api.GET("/notifications/unread-count", handlers.GetUnreadNotificationCount)
+		api.POST("/notifications/read-all", handlers.MarkAllNotificationsRead)
+		api.POST("/notifications/:id/read", handlers.MarkNotificationRead)
+		api.GET("/notification-preferences", handlers.GetNotificationPreferences)
+		api.PUT("/notification-preferences", handlers.UpdateNotificationPreferences)
+
+		// Webhook routes (Company only)
+		webhooks := api.Group("/webhooks")
+		webhooks.Use(middleware.RequireRole(models.RoleCompany))
+		{
+			webhooks.GET("", handlers.GetWebhookEndpoints)
+			webhooks.POST("", handlers.CreateWebhookEndpoint)
+			webhooks.PUT("/:id", handlers.UpdateWebhookEndpoint)
+			webhooks.DELETE("/:id", handlers.DeleteWebhookEndpoint)
+			webhooks.POST("/:id/rotate-secret", handlers.RotateWebhookSecret)
+			webhooks.GET("/:id/deliveries", handlers.GetWebhookDeliveries)
+			webhooks.GET("/:id/deliveries/:delivery_id", handlers.GetWebhookDelivery)
+			webhooks.POST("/:id/deliveries/:delivery_id/redeliver", handlers.RedeliverWebhook)
+		}
+	}
+
+	// Real-time event streams, which also accept ?access_token= for browsers
+	events := r.Group("/api/events")
+	events.Use(middleware.AllowQueryToken(), middleware.AuthMiddleware())
+	{
+		events.GET("/stream", handlers.StreamEvents)
+		events.GET("/ws", handlers.StreamEventsWebSocket)
 	}
 
 	port := os.Getenv("PORT")
@@ -41,6 +41,18 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// AllowQueryToken accepts the token as ?access_token= too.
+func AllowQueryToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
+
 func RequireRole(role models.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("user_role")
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tokenQuery matches the access_token query parameter AllowQueryToken reads.
+var tokenQuery = regexp.MustCompile(`([?&]access_token=)[^&]*`)
+
+// Logger is gin.Logger with access tokens removed from logged URLs.
+func Logger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		var statusColor, methodColor, resetColor string
+		if param.IsOutputColor() {
+			statusColor = param.StatusCodeColor()
+			methodColor = param.MethodColor()
+			resetColor = param.ResetColor()
+		}
+
+		if param.Latency > time.Minute {
+			param.Latency = param.Latency.Truncate(time.Second)
+		}
+		return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			statusColor, param.StatusCode, resetColor,
+			param.Latency,
+			param.ClientIP,
+			methodColor, param.Method, resetColor,
+			RedactQueryToken(param.Path),
+			param.ErrorMessage,
+		)
+	})
+}
+
+// RedactQueryToken replaces the value of an access_token query parameter.
+func RedactQueryToken(path string) string {
+	return tokenQuery.ReplaceAllString(path, "${1}REDACTED")
+}
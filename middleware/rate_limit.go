@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"job-api/models"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// RateLimit keeps an in-memory token bucket per client IP.
+func RateLimit(requestsPerMinute int, burst int) gin.HandlerFunc {
+	var mu sync.Mutex
+	buckets := make(map[string]*bucket)
+	rate := float64(requestsPerMinute) / 60
+	lastSweep := time.Now()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		// Drop buckets that have been idle long enough to be full again
+		if now.Sub(lastSweep) > time.Minute {
+			for key, b := range buckets {
+				if now.Sub(b.lastSeen) > time.Duration(float64(burst)/rate)*time.Second {
+					delete(buckets, key)
+				}
+			}
+			lastSweep = now
+		}
+
+		b, exists := buckets[ip]
+		if !exists {
+			b = &bucket{tokens: float64(burst), lastSeen: now}
+			buckets[ip] = b
+		}
+		b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.lastSeen).Seconds()*rate)
+		b.lastSeen = now
+
+		allowed := b.tokens >= 1
+		if allowed {
+			b.tokens--
+		}
+		remaining := int(b.tokens)
+		retryAfter := int(math.Ceil((1 - b.tokens) / rate))
+		mu.Unlock()
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(requestsPerMinute))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, models.BaseResponse{
+				Success: false,
+				Message: "Too many requests",
+				Object:  nil,
+				Errors:  []string{"Rate limit exceeded"},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
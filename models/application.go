@@ -1,12 +1,17 @@
 package models
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ApplicationStatus is the name of the current pipeline stage. The constants
+// are the stages of the default pipeline.
 type ApplicationStatus string
 
 const (
@@ -15,22 +20,42 @@ const (
 	StatusInterview ApplicationStatus = "Interview"
 	StatusRejected  ApplicationStatus = "Rejected"
 	StatusHired     ApplicationStatus = "Hired"
+
+	// StatusWithdrawn is set by the applicant and is outside any pipeline
+	StatusWithdrawn ApplicationStatus = "Withdrawn"
 )
 
 type Application struct {
-	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ApplicantID uuid.UUID         `json:"applicant_id" gorm:"type:uuid;not null"`
-	JobID       uuid.UUID         `json:"job_id" gorm:"type:uuid;not null"`
-	ResumeLink  string            `json:"resume_link" gorm:"not null" validate:"required,url"`
-	CoverLetter string            `json:"cover_letter" validate:"max=200"`
-	Status      ApplicationStatus `json:"status" gorm:"type:varchar(20);default:'Applied'"`
-	AppliedAt   time.Time         `json:"applied_at"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
+	ID               uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicantID      uuid.UUID         `json:"applicant_id" gorm:"type:uuid;not null"`
+	JobID            uuid.UUID         `json:"job_id" gorm:"type:uuid;not null"`
+	ResumeLink       string            `json:"resume_link" gorm:"not null" validate:"omitempty,url"`
+	ResumeFileID     *uuid.UUID        `json:"resume_file_id" gorm:"type:uuid"`
+	CoverLetter      string            `json:"cover_letter" validate:"max=200"`
+	Status           ApplicationStatus `json:"status" gorm:"type:varchar(100);default:'Applied'"`
+	StageID          *uuid.UUID        `json:"stage_id" gorm:"type:uuid;index"`
+	WithdrawnAt      *time.Time        `json:"withdrawn_at,omitempty"`
+	WithdrawalReason string            `json:"withdrawal_reason,omitempty"`
+	AverageRating    *float64          `json:"average_rating"`
+	ScorecardCount   int               `json:"scorecard_count" gorm:"not null;default:0"`
+	AssigneeID       *uuid.UUID        `json:"assignee_id" gorm:"type:uuid;index"`
+	RevealedAt       *time.Time        `json:"revealed_at,omitempty"`
+	BlindAlias       string            `json:"-" gorm:"type:varchar(16)"`
+	AppliedAt        time.Time         `json:"applied_at"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+
+	// Redacted is set on responses that hide the applicant's identity
+	Redacted bool `json:"redacted,omitempty" gorm:"-"`
 
 	// Relationships
-	Applicant User `json:"applicant" gorm:"foreignKey:ApplicantID"`
-	Job       Job  `json:"job" gorm:"foreignKey:JobID"`
+	Applicant    User              `json:"applicant" gorm:"foreignKey:ApplicantID"`
+	Job          Job               `json:"job" gorm:"foreignKey:JobID"`
+	Stage        *PipelineStage    `json:"stage,omitempty" gorm:"foreignKey:StageID"`
+	ParsedResume *ParsedResume     `json:"parsed_resume,omitempty" gorm:"foreignKey:ApplicationID"`
+	Answers      []ScreeningAnswer `json:"screening_answers,omitempty" gorm:"foreignKey:ApplicationID"`
+	Assignee     *User             `json:"assignee,omitempty" gorm:"foreignKey:AssigneeID"`
+	Tags         []Tag             `json:"tags,omitempty" gorm:"many2many:application_tags"`
 }
 
 func (a *Application) BeforeCreate(tx *gorm.DB) error {
@@ -40,5 +65,16 @@ func (a *Application) BeforeCreate(tx *gorm.DB) error {
 	if a.AppliedAt.IsZero() {
 		a.AppliedAt = time.Now()
 	}
+	if a.BlindAlias == "" {
+		a.BlindAlias = NewBlindAlias()
+	}
 	return nil
 }
+
+func NewBlindAlias() string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return strings.ToUpper(hex.EncodeToString(buf))
+}
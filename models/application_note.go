@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notes are never shown to the applicant.
+type ApplicationNote struct {
+	ID               uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID    uuid.UUID   `json:"application_id" gorm:"type:uuid;not null;index"`
+	AuthorID         uuid.UUID   `json:"author_id" gorm:"type:uuid;not null"`
+	Body             string      `json:"body" gorm:"type:text;not null"`
+	MentionedUserIDs []uuid.UUID `json:"mentioned_user_ids" gorm:"type:jsonb;serializer:json"`
+	EditedAt         *time.Time  `json:"edited_at,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+
+	Author User `json:"author" gorm:"foreignKey:AuthorID"`
+}
+
+func (n *ApplicationNote) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ActorID is nil for changes the system makes on its own.
+type ApplicationStatusEvent struct {
+	ID            uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID uuid.UUID         `json:"application_id" gorm:"type:uuid;not null;index"`
+	FromStatus    ApplicationStatus `json:"from_status" gorm:"type:varchar(100)"`
+	ToStatus      ApplicationStatus `json:"to_status" gorm:"type:varchar(100);not null"`
+	FromStageID   *uuid.UUID        `json:"from_stage_id" gorm:"type:uuid"`
+	ToStageID     *uuid.UUID        `json:"to_stage_id" gorm:"type:uuid"`
+	ActorID       *uuid.UUID        `json:"actor_id" gorm:"type:uuid"`
+	Reason        string            `json:"reason,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+func (e *ApplicationStatusEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApplicationReveal struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID uuid.UUID  `json:"application_id" gorm:"type:uuid;not null;index"`
+	ActorID       *uuid.UUID `json:"actor_id" gorm:"type:uuid"`
+	Automatic     bool       `json:"automatic" gorm:"not null"`
+	Reason        string     `json:"reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+
+	Actor *User `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+}
+
+func (r *ApplicationReveal) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
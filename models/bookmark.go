@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Bookmark struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_bookmark_user_job"`
+	JobID     uuid.UUID `json:"job_id" gorm:"type:uuid;not null;uniqueIndex:idx_bookmark_user_job"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Job Job `json:"job" gorm:"foreignKey:JobID"`
+}
+
+func (b *Bookmark) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
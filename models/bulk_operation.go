@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BulkOperationStatus string
+
+const (
+	BulkOperationQueued    BulkOperationStatus = "queued"
+	BulkOperationRunning   BulkOperationStatus = "running"
+	BulkOperationCompleted BulkOperationStatus = "completed"
+	BulkOperationFailed    BulkOperationStatus = "failed"
+)
+
+type BulkOperation struct {
+	ID             uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID           `json:"organization_id" gorm:"type:uuid;not null;index"`
+	CreatedBy      uuid.UUID           `json:"created_by" gorm:"type:uuid;not null"`
+	Action         string              `json:"action" gorm:"type:varchar(20);not null"`
+	Request        json.RawMessage     `json:"request" gorm:"type:jsonb;not null"`
+	Status         BulkOperationStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Total          int                 `json:"total"`
+	Processed      int                 `json:"processed"`
+	Succeeded      int                 `json:"succeeded"`
+	Failed         int                 `json:"failed"`
+	Results        []BulkItemResult    `json:"results" gorm:"type:jsonb;serializer:json"`
+	Error          string              `json:"error,omitempty"`
+	CompletedAt    *time.Time          `json:"completed_at"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// BulkItemResult is the outcome of a bulk action for one application.
+type BulkItemResult struct {
+	ApplicationID uuid.UUID `json:"application_id"`
+	Success       bool      `json:"success"`
+	Error         string    `json:"error,omitempty"`
+}
+
+func (o *BulkOperation) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
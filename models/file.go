@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FileKind string
+
+const (
+	FileKindResume     FileKind = "resume"
+	FileKindAttachment FileKind = "attachment"
+)
+
+type File struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OwnerID       uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null;index"`
+	ApplicationID *uuid.UUID `json:"application_id" gorm:"type:uuid;index"`
+	MessageID     *uuid.UUID `json:"message_id,omitempty" gorm:"type:uuid;index"`
+	OfferID       *uuid.UUID `json:"offer_id,omitempty" gorm:"type:uuid;index"`
+	Kind          FileKind   `json:"kind" gorm:"type:varchar(20);not null"`
+	FileName      string     `json:"file_name" gorm:"not null"`
+	ContentType   string     `json:"content_type" gorm:"not null"`
+	Size          int64      `json:"size" gorm:"not null"`
+	SHA256        string     `json:"sha256" gorm:"type:varchar(64)"`
+	StorageKey    string     `json:"-" gorm:"not null"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (f *File) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type InterviewStatus string
+
+const (
+	// InterviewProposed interviews have slots offered to the applicant
+	InterviewProposed  InterviewStatus = "proposed"
+	InterviewScheduled InterviewStatus = "scheduled"
+	InterviewCancelled InterviewStatus = "cancelled"
+)
+
+// Sequence counts changes for calendar invite updates.
+type Interview struct {
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID   uuid.UUID       `json:"application_id" gorm:"type:uuid;not null;index"`
+	Title           string          `json:"title" gorm:"not null"`
+	Description     string          `json:"description" gorm:"type:text"`
+	DurationMinutes int             `json:"duration_minutes" gorm:"not null"`
+	Location        string          `json:"location"`
+	VideoLink       string          `json:"video_link"`
+	Status          InterviewStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	StartsAt        *time.Time      `json:"starts_at" gorm:"index"`
+	EndsAt          *time.Time      `json:"ends_at"`
+	Sequence        int             `json:"sequence" gorm:"not null;default:0"`
+	CancelReason    string          `json:"cancel_reason,omitempty"`
+	CreatedBy       uuid.UUID       `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+
+	Slots        []InterviewSlot `json:"slots,omitempty" gorm:"foreignKey:InterviewID"`
+	Interviewers []User          `json:"interviewers" gorm:"many2many:interview_interviewers"`
+}
+
+// InterviewSlot is a start time the company offers for a proposed interview.
+type InterviewSlot struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	InterviewID uuid.UUID `json:"interview_id" gorm:"type:uuid;not null;index"`
+	StartsAt    time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt      time.Time `json:"ends_at" gorm:"not null"`
+}
+
+func (i *Interview) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *InterviewSlot) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
@@ -7,23 +7,67 @@ import (
 	"gorm.io/gorm"
 )
 
+type JobStatus string
+
+const (
+	JobStatusDraft  JobStatus = "Draft"
+	JobStatusOpen   JobStatus = "Open"
+	JobStatusClosed JobStatus = "Closed"
+)
+
 type Job struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Title       string    `json:"title" gorm:"not null" validate:"required,min=1,max=100"`
-	Description string    `json:"description" gorm:"not null" validate:"required,min=20,max=2000"`
-	Location    string    `json:"location"`
-	CreatedBy   uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Title          string     `json:"title" gorm:"not null" validate:"required,min=1,max=100"`
+	Description    string     `json:"description" gorm:"not null" validate:"required,min=20,max=2000"`
+	Location       string     `json:"location"`
+	Skills         []string   `json:"skills" gorm:"type:jsonb;serializer:json"`
+	Status         JobStatus  `json:"status" gorm:"type:varchar(20);default:'Open'"`
+	EmploymentType string     `json:"employment_type" gorm:"type:varchar(20)"`
+	SalaryMin      *float64   `json:"salary_min"`
+	SalaryMax      *float64   `json:"salary_max"`
+	SalaryCurrency string     `json:"salary_currency" gorm:"type:varchar(3)"`
+	SalaryPeriod   string     `json:"salary_period" gorm:"type:varchar(10)"`
+	ValidThrough   *time.Time `json:"valid_through"`
+	PipelineID     *uuid.UUID `json:"pipeline_id" gorm:"type:uuid;index"`
+	CreatedBy      uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;index"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Applicants are hidden until they move past BlindRevealStageID
+	BlindReview        bool       `json:"blind_review" gorm:"not null;default:false"`
+	BlindRevealStageID *uuid.UUID `json:"blind_reveal_stage_id" gorm:"type:uuid"`
+
+	// With CloseWhenFilled, the job closes once Headcount offers are accepted
+	Headcount       *int `json:"headcount"`
+	CloseWhenFilled bool `json:"close_when_filled" gorm:"not null;default:false"`
+
+	ClosingReminderSentAt *time.Time `json:"-"`
 
 	// Relationships
-	Creator      User          `json:"creator" gorm:"foreignKey:CreatedBy"`
-	Applications []Application `json:"applications,omitempty" gorm:"foreignKey:JobID"`
+	Creator      User                `json:"creator" gorm:"foreignKey:CreatedBy"`
+	Pipeline     *Pipeline           `json:"pipeline,omitempty" gorm:"foreignKey:PipelineID"`
+	Questions    []ScreeningQuestion `json:"screening_questions,omitempty" gorm:"foreignKey:JobID"`
+	Applications []Application       `json:"applications,omitempty" gorm:"foreignKey:JobID"`
+}
+
+// PublishedJobs restricts a query to open jobs not past their valid_through.
+func PublishedJobs(db *gorm.DB) *gorm.DB {
+	return db.Where("jobs.status = ? AND (jobs.valid_through IS NULL OR jobs.valid_through > ?)", JobStatusOpen, time.Now())
+}
+
+// DeletedJob lets public listings tell they changed after a job is deleted.
+type DeletedJob struct {
+	JobID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DeletedAt time.Time `gorm:"not null"`
 }
 
 func (j *Job) BeforeCreate(tx *gorm.DB) error {
 	if j.ID == uuid.Nil {
 		j.ID = uuid.New()
 	}
+	if j.Status == "" {
+		j.Status = JobStatusOpen
+	}
 	return nil
 }
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"html"
+	"strings"
+	"time"
+)
+
+// JobPostingLD is a schema.org JobPosting for Google for Jobs.
+type JobPostingLD struct {
+	Context            string            `json:"@context"`
+	Type               string            `json:"@type"`
+	Identifier         PropertyValueLD   `json:"identifier"`
+	Title              string            `json:"title"`
+	Description        string            `json:"description"`
+	URL                string            `json:"url,omitempty"`
+	DatePosted         string            `json:"datePosted"`
+	ValidThrough       string            `json:"validThrough,omitempty"`
+	EmploymentType     string            `json:"employmentType,omitempty"`
+	Skills             string            `json:"skills,omitempty"`
+	HiringOrganization OrganizationLD    `json:"hiringOrganization"`
+	JobLocation        *PlaceLD          `json:"jobLocation,omitempty"`
+	JobLocationType    string            `json:"jobLocationType,omitempty"`
+	BaseSalary         *MonetaryAmountLD `json:"baseSalary,omitempty"`
+}
+
+type PropertyValueLD struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type OrganizationLD struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type PlaceLD struct {
+	Type    string          `json:"@type"`
+	Address PostalAddressLD `json:"address"`
+}
+
+type PostalAddressLD struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality"`
+}
+
+type MonetaryAmountLD struct {
+	Type     string              `json:"@type"`
+	Currency string              `json:"currency"`
+	Value    QuantitativeValueLD `json:"value"`
+}
+
+type QuantitativeValueLD struct {
+	Type     string   `json:"@type"`
+	Value    *float64 `json:"value,omitempty"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	UnitText string   `json:"unitText,omitempty"`
+}
+
+// NewJobPostingLD needs the job's Creator loaded.
+func NewJobPostingLD(job Job, url string) JobPostingLD {
+	posting := JobPostingLD{
+		Context: "https://schema.org/",
+		Type:    "JobPosting",
+		Identifier: PropertyValueLD{
+			Type:  "PropertyValue",
+			Name:  job.Creator.Name,
+			Value: job.ID.String(),
+		},
+		Title:          job.Title,
+		Description:    DescriptionHTML(job.Description),
+		URL:            url,
+		DatePosted:     job.CreatedAt.UTC().Format(time.RFC3339),
+		EmploymentType: job.EmploymentType,
+		Skills:         strings.Join(job.Skills, ", "),
+		HiringOrganization: OrganizationLD{
+			Type: "Organization",
+			Name: job.Creator.Name,
+		},
+	}
+
+	if job.ValidThrough != nil {
+		posting.ValidThrough = job.ValidThrough.UTC().Format(time.RFC3339)
+	}
+
+	location := strings.TrimSpace(job.Location)
+	if strings.Contains(strings.ToLower(location), "remote") {
+		posting.JobLocationType = "TELECOMMUTE"
+	} else if location != "" {
+		posting.JobLocation = &PlaceLD{
+			Type: "Place",
+			Address: PostalAddressLD{
+				Type:            "PostalAddress",
+				AddressLocality: location,
+			},
+		}
+	}
+
+	if job.SalaryCurrency != "" && (job.SalaryMin != nil || job.SalaryMax != nil) {
+		value := QuantitativeValueLD{Type: "QuantitativeValue", UnitText: job.SalaryPeriod}
+		if job.SalaryMin != nil && job.SalaryMax != nil && *job.SalaryMin == *job.SalaryMax {
+			value.Value = job.SalaryMin
+		} else {
+			value.MinValue = job.SalaryMin
+			value.MaxValue = job.SalaryMax
+		}
+		posting.BaseSalary = &MonetaryAmountLD{
+			Type:     "MonetaryAmount",
+			Currency: job.SalaryCurrency,
+			Value:    value,
+		}
+	}
+
+	return posting
+}
+
+// DescriptionHTML escapes a plain-text description, keeping its paragraphs.
+func DescriptionHTML(description string) string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		lines := strings.Split(html.EscapeString(paragraph), "\n")
+		paragraphs = append(paragraphs, "<p>"+strings.Join(lines, "<br>")+"</p>")
+	}
+	return strings.Join(paragraphs, "")
+}
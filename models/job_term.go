@@ -0,0 +1,83 @@
+package models
+
+import (
+	"math"
+	"strings"
+
+	"job-api/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Weight is the job's normalised term frequency.
+type JobTerm struct {
+	JobID  uuid.UUID `json:"job_id" gorm:"type:uuid;primaryKey"`
+	Term   string    `json:"term" gorm:"type:varchar(64);primaryKey;index"`
+	Weight float64   `json:"weight" gorm:"not null"`
+}
+
+func (j *Job) AfterSave(tx *gorm.DB) error {
+	return IndexJobTerms(tx.Session(&gorm.Session{NewDB: true}), j)
+}
+
+func (j *Job) AfterDelete(tx *gorm.DB) error {
+	return tx.Session(&gorm.Session{NewDB: true}).Where("job_id = ?", j.ID).Delete(&JobTerm{}).Error
+}
+
+// The title is repeated so it weighs more than the description.
+func JobText(job *Job) string {
+	return strings.Join([]string{job.Title, job.Title, job.Description, strings.Join(job.Skills, " ")}, " ")
+}
+
+// TermWeights returns the normalised term frequencies of text.
+func TermWeights(text string) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, term := range utils.Tokenize(text) {
+		if len(term) <= 64 {
+			weights[term]++
+		}
+	}
+
+	var norm float64
+	for term, count := range weights {
+		weights[term] = 1 + math.Log(count)
+		norm += weights[term] * weights[term]
+	}
+	norm = math.Sqrt(norm)
+	for term := range weights {
+		weights[term] /= norm
+	}
+	return weights
+}
+
+func IndexJobTerms(db *gorm.DB, job *Job) error {
+	if err := db.Where("job_id = ?", job.ID).Delete(&JobTerm{}).Error; err != nil {
+		return err
+	}
+
+	weights := TermWeights(JobText(job))
+	if len(weights) == 0 {
+		return nil
+	}
+	terms := make([]JobTerm, 0, len(weights))
+	for term, weight := range weights {
+		terms = append(terms, JobTerm{JobID: job.ID, Term: term, Weight: weight})
+	}
+	return db.CreateInBatches(terms, 200).Error
+}
+
+// BackfillJobTerms indexes jobs created before the term index existed.
+func BackfillJobTerms(db *gorm.DB) error {
+	var jobs []Job
+	if err := db.Where("NOT EXISTS (SELECT 1 FROM job_terms WHERE job_terms.job_id = jobs.id)").
+		Find(&jobs).Error; err != nil {
+		return err
+	}
+	for i := range jobs {
+		if err := IndexJobTerms(db, &jobs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
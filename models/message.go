@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReadAt is when the other side first read the message.
+type Message struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID uuid.UUID  `json:"application_id" gorm:"type:uuid;not null;index"`
+	SenderID      uuid.UUID  `json:"sender_id" gorm:"type:uuid;not null"`
+	Body          string     `json:"body" gorm:"type:text;not null"`
+	TemplateID    *uuid.UUID `json:"template_id,omitempty" gorm:"type:uuid"`
+	ReadAt        *time.Time `json:"read_at"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"index"`
+
+	Sender      User   `json:"sender" gorm:"foreignKey:SenderID"`
+	Attachments []File `json:"attachments" gorm:"foreignKey:MessageID"`
+}
+
+// Messages from others created after LastReadAt are unread.
+type MessageThreadRead struct {
+	ApplicationID uuid.UUID `json:"application_id" gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	LastReadAt    time.Time `json:"last_read_at" gorm:"not null"`
+}
+
+// The body may contain placeholders such as {{applicant_name}}.
+type MessageTemplate struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index"`
+	Name           string    `json:"name" gorm:"not null"`
+	Body           string    `json:"body" gorm:"type:text;not null"`
+	CreatedBy      uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// MessageTemplatePlaceholders lists the placeholders a template body may use.
+var MessageTemplatePlaceholders = []string{"applicant_name", "job_title", "company_name", "sender_name"}
+
+func (m *Message) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+func (t *MessageTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// Unknown placeholders are left as they are.
+func (t MessageTemplate) Render(values map[string]string) string {
+	body := t.Body
+	for _, name := range MessageTemplatePlaceholders {
+		body = strings.ReplaceAll(body, "{{"+name+"}}", values[name])
+	}
+	return body
+}
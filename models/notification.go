@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationType string
+
+const (
+	NotificationApplicationSubmitted NotificationType = "application_submitted"
+	NotificationStatusChanged        NotificationType = "application_status_changed"
+	NotificationApplicationWithdrawn NotificationType = "application_withdrawn"
+	NotificationNewApplication       NotificationType = "new_application"
+	NotificationMention              NotificationType = "mention"
+	NotificationInterviewProposed    NotificationType = "interview_proposed"
+	NotificationInterviewScheduled   NotificationType = "interview_scheduled"
+	NotificationInterviewUpdated     NotificationType = "interview_updated"
+	NotificationInterviewCancelled   NotificationType = "interview_cancelled"
+	NotificationMessage              NotificationType = "message"
+	NotificationJobClosingSoon       NotificationType = "job_closing_soon"
+	NotificationWebhookDisabled      NotificationType = "webhook_disabled"
+	NotificationTalentPoolRequest    NotificationType = "talent_pool_request"
+	NotificationJobInvitation        NotificationType = "job_invitation"
+	NotificationOfferApprovalRequest NotificationType = "offer_approval_requested"
+	NotificationOfferApprovalUpdate  NotificationType = "offer_approval_updated"
+	NotificationOfferReceived        NotificationType = "offer_received"
+	NotificationOfferWithdrawn       NotificationType = "offer_withdrawn"
+	NotificationOfferResponded       NotificationType = "offer_responded"
+	NotificationOrganizationInvite   NotificationType = "organization_invitation"
+)
+
+// Data holds the IDs a client needs to link to the subject.
+type Notification struct {
+	ID        uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID         `json:"user_id" gorm:"type:uuid;not null;index"`
+	Type      NotificationType  `json:"type" gorm:"type:varchar(50);not null"`
+	Title     string            `json:"title" gorm:"not null"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data" gorm:"type:jsonb;serializer:json"`
+	ReadAt    *time.Time        `json:"read_at"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailNotificationTypes are the notifications that are also sent by email.
+var EmailNotificationTypes = []NotificationType{
+	NotificationApplicationSubmitted,
+	NotificationStatusChanged,
+	NotificationNewApplication,
+	NotificationInterviewScheduled,
+	NotificationInterviewUpdated,
+	NotificationInterviewCancelled,
+	NotificationJobInvitation,
+	NotificationOfferReceived,
+}
+
+// InAppNotificationTypes are the notifications shown in the in-app feed.
+var InAppNotificationTypes = []NotificationType{
+	NotificationNewApplication,
+	NotificationStatusChanged,
+	NotificationApplicationWithdrawn,
+	NotificationMessage,
+	NotificationJobClosingSoon,
+	NotificationMention,
+	NotificationInterviewProposed,
+	NotificationInterviewScheduled,
+	NotificationInterviewUpdated,
+	NotificationInterviewCancelled,
+	NotificationWebhookDisabled,
+	NotificationTalentPoolRequest,
+	NotificationJobInvitation,
+	NotificationOfferApprovalRequest,
+	NotificationOfferApprovalUpdate,
+	NotificationOfferReceived,
+	NotificationOfferWithdrawn,
+	NotificationOfferResponded,
+	NotificationOrganizationInvite,
+}
+
+// Users without settings get English email for every type.
+type NotificationSettings struct {
+	UserID       uuid.UUID `json:"-" gorm:"type:uuid;primaryKey"`
+	Locale       string    `json:"locale" gorm:"type:varchar(10);not null"`
+	EmailEnabled bool      `json:"email_enabled" gorm:"not null"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Types without a preference are on for every channel.
+type NotificationPreference struct {
+	UserID    uuid.UUID        `json:"-" gorm:"type:uuid;primaryKey"`
+	Type      NotificationType `json:"type" gorm:"type:varchar(50);primaryKey"`
+	Email     bool             `json:"email" gorm:"not null;default:true"`
+	InApp     bool             `json:"in_app" gorm:"not null;default:true"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+func IsEmailNotificationType(notificationType NotificationType) bool {
+	return containsNotificationType(EmailNotificationTypes, notificationType)
+}
+
+func IsInAppNotificationType(notificationType NotificationType) bool {
+	return containsNotificationType(InAppNotificationTypes, notificationType)
+}
+
+func containsNotificationType(types []NotificationType, notificationType NotificationType) bool {
+	for _, t := range types {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OfferStatus string
+
+const (
+	OfferDraft           OfferStatus = "draft"
+	OfferPendingApproval OfferStatus = "pending_approval"
+	// Rejected by an approver; can be edited and submitted again
+	OfferRejected  OfferStatus = "rejected"
+	OfferApproved  OfferStatus = "approved"
+	OfferSent      OfferStatus = "sent"
+	OfferAccepted  OfferStatus = "accepted"
+	OfferDeclined  OfferStatus = "declined"
+	OfferExpired   OfferStatus = "expired"
+	OfferWithdrawn OfferStatus = "withdrawn"
+)
+
+type OfferApprovalStatus string
+
+const (
+	OfferApprovalPending  OfferApprovalStatus = "pending"
+	OfferApprovalApproved OfferApprovalStatus = "approved"
+	OfferApprovalRejected OfferApprovalStatus = "rejected"
+)
+
+// Approvers sign an offer off in order before it can be sent.
+type Offer struct {
+	ID                uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID     uuid.UUID   `json:"application_id" gorm:"type:uuid;not null;index"`
+	JobID             uuid.UUID   `json:"job_id" gorm:"type:uuid;not null;index"`
+	Status            OfferStatus `json:"status" gorm:"type:varchar(20);not null"`
+	SalaryAmount      float64     `json:"salary_amount" gorm:"not null"`
+	SalaryCurrency    string      `json:"salary_currency" gorm:"type:varchar(3);not null"`
+	SalaryPeriod      string      `json:"salary_period" gorm:"type:varchar(10);not null"`
+	CompensationNotes string      `json:"compensation_notes,omitempty" gorm:"type:text"`
+	StartDate         time.Time   `json:"start_date" gorm:"type:date;not null"`
+	ExpiresAt         time.Time   `json:"expires_at" gorm:"not null"`
+	TermsFileID       *uuid.UUID  `json:"terms_file_id" gorm:"type:uuid"`
+	Message           string      `json:"message,omitempty" gorm:"type:text"`
+	DeclineReason     string      `json:"decline_reason,omitempty"`
+	CreatedBy         uuid.UUID   `json:"created_by" gorm:"type:uuid;not null"`
+	SentAt            *time.Time  `json:"sent_at"`
+	RespondedAt       *time.Time  `json:"responded_at"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+
+	// Relationships
+	Approvals []OfferApproval `json:"approvals,omitempty" gorm:"foreignKey:OfferID"`
+	TermsFile *File           `json:"terms_file,omitempty" gorm:"foreignKey:TermsFileID"`
+}
+
+func (o *Offer) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+func (o Offer) Open() bool {
+	switch o.Status {
+	case OfferDraft, OfferPendingApproval, OfferRejected, OfferApproved, OfferSent:
+		return true
+	}
+	return false
+}
+
+func (o Offer) Editable() bool {
+	return o.Open() && o.Status != OfferSent
+}
+
+// OfferApproval is one step in an offer's approval chain.
+type OfferApproval struct {
+	ID         uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OfferID    uuid.UUID           `json:"offer_id" gorm:"type:uuid;not null;index"`
+	ApproverID uuid.UUID           `json:"approver_id" gorm:"type:uuid;not null"`
+	Position   int                 `json:"position" gorm:"not null"`
+	Status     OfferApprovalStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Comment    string              `json:"comment,omitempty"`
+	DecidedAt  *time.Time          `json:"decided_at"`
+
+	Approver User `json:"approver" gorm:"foreignKey:ApproverID"`
+}
+
+func (a *OfferApproval) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// The company account itself is the organization.
+type OrganizationMember struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	AddedBy        uuid.UUID `json:"added_by" gorm:"type:uuid;not null"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (m *OrganizationMember) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+type OrganizationInvitationStatus string
+
+const (
+	OrganizationInvitationPending  OrganizationInvitationStatus = "pending"
+	OrganizationInvitationAccepted OrganizationInvitationStatus = "accepted"
+	OrganizationInvitationDeclined OrganizationInvitationStatus = "declined"
+)
+
+type OrganizationInvitation struct {
+	ID             uuid.UUID                    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID                    `json:"organization_id" gorm:"type:uuid;not null;index"`
+	UserID         uuid.UUID                    `json:"user_id" gorm:"type:uuid;not null;index"`
+	Status         OrganizationInvitationStatus `json:"status" gorm:"type:varchar(20);not null"`
+	InvitedBy      uuid.UUID                    `json:"invited_by" gorm:"type:uuid;not null"`
+	RespondedAt    *time.Time                   `json:"responded_at"`
+	CreatedAt      time.Time                    `json:"created_at"`
+
+	Organization User `json:"organization" gorm:"foreignKey:OrganizationID"`
+	User         User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (i *OrganizationInvitation) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"job-api/resume"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ResumeParseStatus string
+
+const (
+	ResumeParsed      ResumeParseStatus = "parsed"
+	ResumeParseFailed ResumeParseStatus = "failed"
+)
+
+// A parsed resume follows its file onto the application it is used for.
+type ParsedResume struct {
+	ID              uuid.UUID          `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FileID          uuid.UUID          `json:"file_id" gorm:"type:uuid;not null;uniqueIndex"`
+	OwnerID         uuid.UUID          `json:"owner_id" gorm:"type:uuid;not null;index"`
+	ApplicationID   *uuid.UUID         `json:"application_id" gorm:"type:uuid;index"`
+	Parser          string             `json:"parser" gorm:"type:varchar(50);not null"`
+	Status          ResumeParseStatus  `json:"status" gorm:"type:varchar(20);not null"`
+	Error           string             `json:"error,omitempty"`
+	Text            string             `json:"text,omitempty" gorm:"type:text"`
+	Contact         resume.Contact     `json:"contact" gorm:"type:jsonb;serializer:json"`
+	Skills          []string           `json:"skills" gorm:"type:jsonb;serializer:json"`
+	Employers       []resume.Employer  `json:"employers" gorm:"type:jsonb;serializer:json"`
+	Education       []resume.Education `json:"education" gorm:"type:jsonb;serializer:json"`
+	YearsExperience *float64           `json:"years_experience"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+func (p *ParsedResume) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
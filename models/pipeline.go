@@ -0,0 +1,241 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StageCategory string
+
+const (
+	StageCategoryActive   StageCategory = "active"
+	StageCategoryRejected StageCategory = "rejected"
+	StageCategoryHired    StageCategory = "hired"
+)
+
+// The default pipeline has no company and mirrors the original statuses.
+type Pipeline struct {
+	ID        uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CompanyID *uuid.UUID      `json:"company_id" gorm:"type:uuid;index"`
+	Name      string          `json:"name" gorm:"not null"`
+	IsDefault bool            `json:"is_default" gorm:"not null;default:false"`
+	Stages    []PipelineStage `json:"stages" gorm:"foreignKey:PipelineID"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type PipelineStage struct {
+	ID         uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PipelineID uuid.UUID     `json:"pipeline_id" gorm:"type:uuid;not null;index"`
+	Name       string        `json:"name" gorm:"type:varchar(100);not null"`
+	Position   int           `json:"position" gorm:"not null"`
+	Category   StageCategory `json:"category" gorm:"type:varchar(20);not null"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+func (p *Pipeline) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *PipelineStage) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s PipelineStage) IsTerminal() bool {
+	return s.Category != StageCategoryActive
+}
+
+// OrderedStages returns the stages sorted by position.
+func (p *Pipeline) OrderedStages() []PipelineStage {
+	stages := append([]PipelineStage(nil), p.Stages...)
+	sort.Slice(stages, func(i, j int) bool {
+		return stages[i].Position < stages[j].Position
+	})
+	return stages
+}
+
+// FirstStage is the stage new applications enter.
+func (p *Pipeline) FirstStage() (PipelineStage, error) {
+	stages := p.OrderedStages()
+	if len(stages) == 0 || stages[0].Category != StageCategoryActive {
+		return PipelineStage{}, errors.New("pipeline has no initial active stage")
+	}
+	return stages[0], nil
+}
+
+// FindStage looks a stage up by ID or, when id is nil, by case-insensitive name.
+func (p *Pipeline) FindStage(id *uuid.UUID, name string) (PipelineStage, bool) {
+	for _, stage := range p.Stages {
+		if id != nil && stage.ID == *id {
+			return stage, true
+		}
+		if id == nil && strings.EqualFold(stage.Name, name) {
+			return stage, true
+		}
+	}
+	return PipelineStage{}, false
+}
+
+// HiredStage is the first stage applications are hired into.
+func (p *Pipeline) HiredStage() (PipelineStage, bool) {
+	for _, stage := range p.OrderedStages() {
+		if stage.Category == StageCategoryHired {
+			return stage, true
+		}
+	}
+	return PipelineStage{}, false
+}
+
+// RejectedStage is the first stage applications are rejected into.
+func (p *Pipeline) RejectedStage() (PipelineStage, bool) {
+	for _, stage := range p.OrderedStages() {
+		if stage.Category == StageCategoryRejected {
+			return stage, true
+		}
+	}
+	return PipelineStage{}, false
+}
+
+// Rejected and hired stages are final, and hired ones need the application
+// to have left the first stage.
+func (p *Pipeline) AllowedTransitions(from PipelineStage) []PipelineStage {
+	if from.IsTerminal() {
+		return nil
+	}
+
+	stages := p.OrderedStages()
+	isFirst := len(stages) > 0 && stages[0].ID == from.ID
+
+	var allowed []PipelineStage
+	for _, stage := range stages {
+		switch stage.Category {
+		case StageCategoryActive:
+			if stage.Position > from.Position {
+				allowed = append(allowed, stage)
+			}
+		case StageCategoryRejected:
+			allowed = append(allowed, stage)
+		case StageCategoryHired:
+			if !isFirst {
+				allowed = append(allowed, stage)
+			}
+		}
+	}
+	return allowed
+}
+
+func (p *Pipeline) ValidateTransition(from, to PipelineStage) error {
+	if from.ID == to.ID {
+		return fmt.Errorf("application is already in stage %s", from.Name)
+	}
+	if from.IsTerminal() {
+		return fmt.Errorf("cannot move application from %s to %s: %s is a final stage", from.Name, to.Name, from.Name)
+	}
+
+	allowed := p.AllowedTransitions(from)
+	names := make([]string, len(allowed))
+	for i, stage := range allowed {
+		if stage.ID == to.ID {
+			return nil
+		}
+		names[i] = stage.Name
+	}
+	return fmt.Errorf("cannot move application from %s to %s: allowed next stages are %s",
+		from.Name, to.Name, strings.Join(names, ", "))
+}
+
+// ValidateStages checks that a list of stages forms a usable pipeline.
+func ValidateStages(stages []PipelineStage) error {
+	if len(stages) == 0 {
+		return errors.New("pipeline must have at least one stage")
+	}
+
+	ordered := append([]PipelineStage(nil), stages...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Position < ordered[j].Position
+	})
+	if ordered[0].Category != StageCategoryActive {
+		return errors.New("the first stage must be an active stage")
+	}
+
+	names := make(map[string]bool)
+	categories := make(map[StageCategory]bool)
+	for _, stage := range ordered {
+		key := strings.ToLower(strings.TrimSpace(stage.Name))
+		if key == strings.ToLower(string(StatusWithdrawn)) {
+			return fmt.Errorf("stage name %q is reserved", stage.Name)
+		}
+		if names[key] {
+			return fmt.Errorf("duplicate stage name %q", stage.Name)
+		}
+		names[key] = true
+		categories[stage.Category] = true
+	}
+	if !categories[StageCategoryRejected] {
+		return errors.New("pipeline must have a rejected stage")
+	}
+	if !categories[StageCategoryHired] {
+		return errors.New("pipeline must have a hired stage")
+	}
+	return nil
+}
+
+// defaultStages mirrors the original fixed ApplicationStatus values.
+var defaultStages = []PipelineStage{
+	{Name: string(StatusApplied), Position: 0, Category: StageCategoryActive},
+	{Name: string(StatusReviewed), Position: 1, Category: StageCategoryActive},
+	{Name: string(StatusInterview), Position: 2, Category: StageCategoryActive},
+	{Name: string(StatusRejected), Position: 3, Category: StageCategoryRejected},
+	{Name: string(StatusHired), Position: 4, Category: StageCategoryHired},
+}
+
+func DefaultPipeline(db *gorm.DB) (Pipeline, error) {
+	var pipeline Pipeline
+	err := db.Preload("Stages").
+		Where("is_default = ? AND company_id IS NULL", true).
+		First(&pipeline).Error
+	return pipeline, err
+}
+
+func MigrateDefaultPipeline(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		pipeline, err := DefaultPipeline(tx)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pipeline = Pipeline{Name: "Default", IsDefault: true}
+			for _, stage := range defaultStages {
+				pipeline.Stages = append(pipeline.Stages, stage)
+			}
+			err = tx.Create(&pipeline).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&Job{}).Where("pipeline_id IS NULL").
+			UpdateColumn("pipeline_id", pipeline.ID).Error; err != nil {
+			return err
+		}
+
+		for _, stage := range pipeline.Stages {
+			if err := tx.Model(&Application{}).
+				Where("stage_id IS NULL AND status = ?", stage.Name).
+				UpdateColumn("stage_id", stage.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
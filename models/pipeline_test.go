@@ -0,0 +1,55 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPipelineValidateTransition(t *testing.T) {
+	applied := PipelineStage{ID: uuid.New(), Name: "Applied", Position: 0, Category: StageCategoryActive}
+	screening := PipelineStage{ID: uuid.New(), Name: "Screening", Position: 1, Category: StageCategoryActive}
+	interview := PipelineStage{ID: uuid.New(), Name: "Interview", Position: 2, Category: StageCategoryActive}
+	rejected := PipelineStage{ID: uuid.New(), Name: "Rejected", Position: 3, Category: StageCategoryRejected}
+	hired := PipelineStage{ID: uuid.New(), Name: "Hired", Position: 4, Category: StageCategoryHired}
+	// Stored out of order, as they may come back from the database
+	pipeline := Pipeline{Stages: []PipelineStage{hired, interview, applied, rejected, screening}}
+
+	tests := []struct {
+		name     string
+		from, to PipelineStage
+		wantErr  string
+	}{
+		{name: "next active stage", from: applied, to: screening},
+		{name: "skipping an active stage", from: applied, to: interview},
+		{name: "rejected from the first stage", from: applied, to: rejected},
+		{name: "rejected from a later stage", from: interview, to: rejected},
+		{name: "hired after the first stage", from: screening, to: hired},
+		{name: "same stage", from: screening, to: screening, wantErr: "application is already in stage Screening"},
+		{name: "backwards", from: interview, to: screening,
+			wantErr: "cannot move application from Interview to Screening: allowed next stages are Rejected, Hired"},
+		{name: "hired from the first stage", from: applied, to: hired,
+			wantErr: "cannot move application from Applied to Hired: allowed next stages are Screening, Interview, Rejected"},
+		{name: "out of rejected", from: rejected, to: screening,
+			wantErr: "cannot move application from Rejected to Screening: Rejected is a final stage"},
+		{name: "out of hired", from: hired, to: rejected,
+			wantErr: "cannot move application from Hired to Rejected: Hired is a final stage"},
+		{name: "stage of another pipeline", from: applied, to: PipelineStage{ID: uuid.New(), Name: "Offer", Position: 1, Category: StageCategoryActive},
+			wantErr: "allowed next stages are Screening, Interview, Rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pipeline.ValidateTransition(tt.from, tt.to)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateTransition() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateTransition() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
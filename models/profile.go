@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApplicantProfile struct {
+	ID                 uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID             uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	Headline           string    `json:"headline"`
+	Skills             []string  `json:"skills" gorm:"type:jsonb;serializer:json"`
+	PreferredLocations []string  `json:"preferred_locations" gorm:"type:jsonb;serializer:json"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+func (p *ApplicantProfile) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
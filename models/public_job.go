@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PublicCompany is what anonymous visitors may see of a company.
+type PublicCompany struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type PublicJob struct {
+	ID             uuid.UUID     `json:"id"`
+	Title          string        `json:"title"`
+	Description    string        `json:"description"`
+	Location       string        `json:"location"`
+	Skills         []string      `json:"skills"`
+	EmploymentType string        `json:"employment_type,omitempty"`
+	SalaryMin      *float64      `json:"salary_min,omitempty"`
+	SalaryMax      *float64      `json:"salary_max,omitempty"`
+	SalaryCurrency string        `json:"salary_currency,omitempty"`
+	SalaryPeriod   string        `json:"salary_period,omitempty"`
+	ValidThrough   *time.Time    `json:"valid_through,omitempty"`
+	Company        PublicCompany `json:"company"`
+	PostedAt       time.Time     `json:"posted_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+func NewPublicJob(job Job) PublicJob {
+	return PublicJob{
+		ID:             job.ID,
+		Title:          job.Title,
+		Description:    job.Description,
+		Location:       job.Location,
+		Skills:         job.Skills,
+		EmploymentType: job.EmploymentType,
+		SalaryMin:      job.SalaryMin,
+		SalaryMax:      job.SalaryMax,
+		SalaryCurrency: job.SalaryCurrency,
+		SalaryPeriod:   job.SalaryPeriod,
+		ValidThrough:   job.ValidThrough,
+		Company: PublicCompany{
+			ID:   job.Creator.ID,
+			Name: job.Creator.Name,
+		},
+		PostedAt:  job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+}
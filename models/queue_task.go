@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QueueTaskStatus string
+
+const (
+	QueueTaskPending   QueueTaskStatus = "pending"
+	QueueTaskRunning   QueueTaskStatus = "running"
+	QueueTaskSucceeded QueueTaskStatus = "succeeded"
+	// Dead tasks are not retried and are kept for inspection
+	QueueTaskDead QueueTaskStatus = "dead"
+)
+
+// Tasks are written in the same transaction as the change that causes them,
+// so they are neither lost nor run for rolled back changes.
+type QueueTask struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Kind        string          `json:"kind" gorm:"type:varchar(50);not null;index"`
+	Payload     json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	Status      QueueTaskStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_queue_tasks_due,priority:1"`
+	RunAt       time.Time       `json:"run_at" gorm:"not null;index:idx_queue_tasks_due,priority:2"`
+	Attempts    int             `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int             `json:"max_attempts" gorm:"not null"`
+	LockedAt    *time.Time      `json:"locked_at"`
+	LastError   string          `json:"last_error,omitempty" gorm:"type:text"`
+	CompletedAt *time.Time      `json:"completed_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+func (t *QueueTask) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
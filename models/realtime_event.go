@@ -0,0 +1,19 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clients see Seq as the ID. It follows the order events are delivered in,
+// while ID is taken before the event's transaction commits.
+type RealtimeEvent struct {
+	ID        int64           `json:"-" gorm:"primaryKey;autoIncrement"`
+	Seq       *int64          `json:"id" gorm:"uniqueIndex"`
+	Type      string          `json:"type" gorm:"type:varchar(50);not null"`
+	UserIDs   []uuid.UUID     `json:"-" gorm:"type:jsonb;serializer:json;not null"`
+	Data      json.RawMessage `json:"data" gorm:"type:jsonb"`
+	CreatedAt time.Time       `json:"created_at" gorm:"index"`
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CriterionType string
+
+const (
+	CriterionRating CriterionType = "rating"
+	CriterionText   CriterionType = "text"
+)
+
+type Recommendation string
+
+const (
+	RecommendStrongNo  Recommendation = "strong_no"
+	RecommendNo        Recommendation = "no"
+	RecommendYes       Recommendation = "yes"
+	RecommendStrongYes Recommendation = "strong_yes"
+)
+
+const ratingScale = 5
+
+// Rating criteria are scored from 1 to Scale.
+type ScorecardCriterion struct {
+	ID          uuid.UUID     `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Type        CriterionType `json:"type"`
+	Scale       int           `json:"scale,omitempty"`
+	Required    bool          `json:"required"`
+}
+
+type ScorecardTemplate struct {
+	ID        uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	JobID     uuid.UUID            `json:"job_id" gorm:"type:uuid;not null;index"`
+	Name      string               `json:"name" gorm:"not null"`
+	Criteria  []ScorecardCriterion `json:"criteria" gorm:"type:jsonb;serializer:json"`
+	CreatedBy uuid.UUID            `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type ScorecardRating struct {
+	CriterionID uuid.UUID `json:"criterion_id"`
+	Rating      *int      `json:"rating,omitempty"`
+	Comment     string    `json:"comment,omitempty"`
+}
+
+// OverallRating is the mean of the rating criteria on a 1-5 scale.
+type Scorecard struct {
+	ID             uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID  uuid.UUID         `json:"application_id" gorm:"type:uuid;not null;uniqueIndex:idx_scorecard_interviewer"`
+	TemplateID     uuid.UUID         `json:"template_id" gorm:"type:uuid;not null;uniqueIndex:idx_scorecard_interviewer"`
+	InterviewerID  uuid.UUID         `json:"interviewer_id" gorm:"type:uuid;not null;uniqueIndex:idx_scorecard_interviewer"`
+	Ratings        []ScorecardRating `json:"ratings" gorm:"type:jsonb;serializer:json"`
+	OverallRating  *float64          `json:"overall_rating"`
+	Recommendation Recommendation    `json:"recommendation" gorm:"type:varchar(20);not null"`
+	Summary        string            `json:"summary" gorm:"type:text"`
+	SubmittedAt    time.Time         `json:"submitted_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+
+	Interviewer User `json:"interviewer" gorm:"foreignKey:InterviewerID"`
+}
+
+func (t *ScorecardTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (s *Scorecard) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// ValidateCriteria checks a template's criteria and assigns IDs to new ones.
+func (t *ScorecardTemplate) ValidateCriteria() error {
+	if len(t.Criteria) == 0 {
+		return errors.New("a scorecard needs at least one criterion")
+	}
+
+	names := make(map[string]bool)
+	for i := range t.Criteria {
+		criterion := &t.Criteria[i]
+		criterion.Name = strings.TrimSpace(criterion.Name)
+		key := strings.ToLower(criterion.Name)
+		if key == "" {
+			return errors.New("criteria need a name")
+		}
+		if names[key] {
+			return fmt.Errorf("duplicate criterion %q", criterion.Name)
+		}
+		names[key] = true
+
+		switch criterion.Type {
+		case CriterionRating:
+			if criterion.Scale < 2 || criterion.Scale > 10 {
+				return fmt.Errorf("criterion %q needs a scale between 2 and 10", criterion.Name)
+			}
+		case CriterionText:
+			criterion.Scale = 0
+		default:
+			return fmt.Errorf("criterion %q has unknown type %q", criterion.Name, criterion.Type)
+		}
+
+		if criterion.ID == uuid.Nil {
+			criterion.ID = uuid.New()
+		}
+	}
+	return nil
+}
+
+// Score returns nil when the template has no rated criteria.
+func (t ScorecardTemplate) Score(ratings []ScorecardRating) (*float64, error) {
+	given := make(map[uuid.UUID]ScorecardRating, len(ratings))
+	for _, rating := range ratings {
+		if _, ok := given[rating.CriterionID]; ok {
+			return nil, fmt.Errorf("criterion %s was rated more than once", rating.CriterionID)
+		}
+		given[rating.CriterionID] = rating
+	}
+
+	var total float64
+	var rated int
+	for _, criterion := range t.Criteria {
+		rating, ok := given[criterion.ID]
+		delete(given, criterion.ID)
+
+		switch criterion.Type {
+		case CriterionRating:
+			if !ok || rating.Rating == nil {
+				if criterion.Required {
+					return nil, fmt.Errorf("criterion %q requires a rating", criterion.Name)
+				}
+				continue
+			}
+			if *rating.Rating < 1 || *rating.Rating > criterion.Scale {
+				return nil, fmt.Errorf("rating for %q must be between 1 and %d", criterion.Name, criterion.Scale)
+			}
+			total += float64(*rating.Rating) / float64(criterion.Scale) * ratingScale
+			rated++
+		case CriterionText:
+			if ok && rating.Rating != nil {
+				return nil, fmt.Errorf("criterion %q takes a comment, not a rating", criterion.Name)
+			}
+			if criterion.Required && (!ok || strings.TrimSpace(rating.Comment) == "") {
+				return nil, fmt.Errorf("criterion %q requires a comment", criterion.Name)
+			}
+		}
+	}
+
+	for criterionID := range given {
+		return nil, fmt.Errorf("criterion %s is not part of this scorecard", criterionID)
+	}
+
+	if rated == 0 {
+		return nil, nil
+	}
+	overall := math.Round(total/float64(rated)*100) / 100
+	return &overall, nil
+}
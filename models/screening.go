@@ -0,0 +1,261 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionType string
+
+const (
+	QuestionYesNo        QuestionType = "yes_no"
+	QuestionSingleChoice QuestionType = "single_choice"
+	QuestionMultiChoice  QuestionType = "multi_choice"
+	QuestionNumber       QuestionType = "number"
+	QuestionShortText    QuestionType = "short_text"
+)
+
+const maxShortTextAnswer = 500
+
+// Only the fields for the question's type apply.
+type KnockoutRule struct {
+	ExpectedAnswer  *bool    `json:"expected_answer,omitempty"`
+	AcceptedOptions []string `json:"accepted_options,omitempty"`
+	RequiredOptions []string `json:"required_options,omitempty"`
+	Min             *float64 `json:"min,omitempty"`
+	Max             *float64 `json:"max,omitempty"`
+}
+
+type ScreeningQuestion struct {
+	ID        uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	JobID     uuid.UUID     `json:"job_id" gorm:"type:uuid;not null;index"`
+	Position  int           `json:"position" gorm:"not null"`
+	Prompt    string        `json:"prompt" gorm:"not null"`
+	Type      QuestionType  `json:"type" gorm:"type:varchar(20);not null"`
+	Options   []string      `json:"options,omitempty" gorm:"type:jsonb;serializer:json"`
+	Required  bool          `json:"required" gorm:"not null;default:false"`
+	Knockout  *KnockoutRule `json:"knockout,omitempty" gorm:"type:jsonb;serializer:json"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// The prompt is copied so answers stay readable if the question is removed.
+type ScreeningAnswer struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID uuid.UUID `json:"application_id" gorm:"type:uuid;not null;index"`
+	QuestionID    uuid.UUID `json:"question_id" gorm:"type:uuid;not null"`
+	Prompt        string    `json:"prompt" gorm:"not null"`
+	Value         any       `json:"value" gorm:"type:jsonb;serializer:json"`
+	KnockedOut    bool      `json:"knocked_out" gorm:"not null;default:false"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (q *ScreeningQuestion) BeforeCreate(tx *gorm.DB) error {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.New()
+	}
+	return nil
+}
+
+func (a *ScreeningAnswer) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// Validate checks that the question's options and knockout rule fit its type.
+func (q ScreeningQuestion) Validate() error {
+	switch q.Type {
+	case QuestionSingleChoice, QuestionMultiChoice:
+		if len(q.Options) < 2 {
+			return fmt.Errorf("question %q needs at least two options", q.Prompt)
+		}
+		seen := make(map[string]bool)
+		for _, option := range q.Options {
+			if seen[option] {
+				return fmt.Errorf("question %q has duplicate option %q", q.Prompt, option)
+			}
+			seen[option] = true
+		}
+	case QuestionYesNo, QuestionNumber, QuestionShortText:
+		if len(q.Options) > 0 {
+			return fmt.Errorf("question %q of type %s does not take options", q.Prompt, q.Type)
+		}
+	default:
+		return fmt.Errorf("question %q has unknown type %q", q.Prompt, q.Type)
+	}
+
+	if q.Knockout == nil {
+		return nil
+	}
+	// Optional questions could be left blank to get past the rule
+	if !q.Required {
+		return fmt.Errorf("question %q has a knockout rule and must be required", q.Prompt)
+	}
+	rule := q.Knockout
+	invalid := fmt.Errorf("question %q has a knockout rule that does not match its type", q.Prompt)
+
+	switch q.Type {
+	case QuestionYesNo:
+		if rule.ExpectedAnswer == nil || len(rule.AcceptedOptions) > 0 || len(rule.RequiredOptions) > 0 || rule.Min != nil || rule.Max != nil {
+			return invalid
+		}
+	case QuestionSingleChoice:
+		if len(rule.AcceptedOptions) == 0 || rule.ExpectedAnswer != nil || len(rule.RequiredOptions) > 0 || rule.Min != nil || rule.Max != nil {
+			return invalid
+		}
+		if err := q.checkOptions(rule.AcceptedOptions); err != nil {
+			return err
+		}
+	case QuestionMultiChoice:
+		if len(rule.RequiredOptions) == 0 || rule.ExpectedAnswer != nil || len(rule.AcceptedOptions) > 0 || rule.Min != nil || rule.Max != nil {
+			return invalid
+		}
+		if err := q.checkOptions(rule.RequiredOptions); err != nil {
+			return err
+		}
+	case QuestionNumber:
+		if (rule.Min == nil && rule.Max == nil) || rule.ExpectedAnswer != nil || len(rule.AcceptedOptions) > 0 || len(rule.RequiredOptions) > 0 {
+			return invalid
+		}
+		if rule.Min != nil && rule.Max != nil && *rule.Max < *rule.Min {
+			return fmt.Errorf("question %q has a knockout max below its min", q.Prompt)
+		}
+	case QuestionShortText:
+		return fmt.Errorf("short text question %q cannot have a knockout rule", q.Prompt)
+	}
+	return nil
+}
+
+func (q ScreeningQuestion) checkOptions(options []string) error {
+	for _, option := range options {
+		if !q.hasOption(option) {
+			return fmt.Errorf("question %q has no option %q", q.Prompt, option)
+		}
+	}
+	return nil
+}
+
+func (q ScreeningQuestion) hasOption(option string) bool {
+	for _, candidate := range q.Options {
+		if candidate == option {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeAnswer returns a bool, string, []string or float64, or nil when
+// the question was left unanswered.
+func (q ScreeningQuestion) NormalizeAnswer(value any) (any, error) {
+	switch q.Type {
+	case QuestionYesNo:
+		if answer, ok := value.(bool); ok {
+			return answer, nil
+		}
+		return nil, fmt.Errorf("answer to %q must be true or false", q.Prompt)
+
+	case QuestionSingleChoice:
+		if answer, ok := value.(string); ok && q.hasOption(answer) {
+			return answer, nil
+		}
+		return nil, fmt.Errorf("answer to %q must be one of: %s", q.Prompt, strings.Join(q.Options, ", "))
+
+	case QuestionMultiChoice:
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("answer to %q must be a list of options", q.Prompt)
+		}
+		seen := make(map[string]bool)
+		answer := make([]string, 0, len(items))
+		for _, item := range items {
+			option, ok := item.(string)
+			if !ok || !q.hasOption(option) {
+				return nil, fmt.Errorf("answer to %q may only contain: %s", q.Prompt, strings.Join(q.Options, ", "))
+			}
+			if !seen[option] {
+				seen[option] = true
+				answer = append(answer, option)
+			}
+		}
+		if q.Required && len(answer) == 0 {
+			return nil, fmt.Errorf("question %q requires at least one option", q.Prompt)
+		}
+		return answer, nil
+
+	case QuestionNumber:
+		if answer, ok := value.(float64); ok && !math.IsInf(answer, 0) && !math.IsNaN(answer) {
+			return answer, nil
+		}
+		return nil, fmt.Errorf("answer to %q must be a number", q.Prompt)
+
+	case QuestionShortText:
+		answer, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("answer to %q must be text", q.Prompt)
+		}
+		answer = strings.TrimSpace(answer)
+		if q.Required && answer == "" {
+			return nil, fmt.Errorf("question %q requires an answer", q.Prompt)
+		}
+		if len(answer) > maxShortTextAnswer {
+			return nil, fmt.Errorf("answer to %q must be at most %d characters", q.Prompt, maxShortTextAnswer)
+		}
+		return answer, nil
+	}
+	return nil, errors.New("unknown question type")
+}
+
+// Questions without a knockout rule always pass.
+func (q ScreeningQuestion) PassesKnockout(answer any) bool {
+	rule := q.Knockout
+	if rule == nil {
+		return true
+	}
+
+	switch q.Type {
+	case QuestionYesNo:
+		value, ok := answer.(bool)
+		return ok && rule.ExpectedAnswer != nil && value == *rule.ExpectedAnswer
+	case QuestionSingleChoice:
+		value, _ := answer.(string)
+		for _, option := range rule.AcceptedOptions {
+			if option == value {
+				return true
+			}
+		}
+		return false
+	case QuestionMultiChoice:
+		values, _ := answer.([]string)
+		selected := make(map[string]bool, len(values))
+		for _, value := range values {
+			selected[value] = true
+		}
+		for _, option := range rule.RequiredOptions {
+			if !selected[option] {
+				return false
+			}
+		}
+		return true
+	case QuestionNumber:
+		value, ok := answer.(float64)
+		if !ok {
+			return false
+		}
+		if rule.Min != nil && value < *rule.Min {
+			return false
+		}
+		if rule.Max != nil && value > *rule.Max {
+			return false
+		}
+		return true
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package models
+
+import "testing"
+
+func TestScreeningQuestionPassesKnockout(t *testing.T) {
+	yes := true
+	low, high := 2.0, 10.0
+
+	tests := []struct {
+		name     string
+		question ScreeningQuestion
+		answer   any
+		want     bool
+	}{
+		{"no rule", ScreeningQuestion{Type: QuestionYesNo}, false, true},
+		{"yes/no expected answer", ScreeningQuestion{Type: QuestionYesNo, Knockout: &KnockoutRule{ExpectedAnswer: &yes}}, true, true},
+		{"yes/no other answer", ScreeningQuestion{Type: QuestionYesNo, Knockout: &KnockoutRule{ExpectedAnswer: &yes}}, false, false},
+		{"yes/no unanswered", ScreeningQuestion{Type: QuestionYesNo, Knockout: &KnockoutRule{ExpectedAnswer: &yes}}, nil, false},
+		{"yes/no rule without expected answer", ScreeningQuestion{Type: QuestionYesNo, Knockout: &KnockoutRule{}}, true, false},
+		{"single choice accepted", ScreeningQuestion{Type: QuestionSingleChoice,
+			Knockout: &KnockoutRule{AcceptedOptions: []string{"EU", "UK"}}}, "UK", true},
+		{"single choice not accepted", ScreeningQuestion{Type: QuestionSingleChoice,
+			Knockout: &KnockoutRule{AcceptedOptions: []string{"EU", "UK"}}}, "US", false},
+		{"single choice matches case", ScreeningQuestion{Type: QuestionSingleChoice,
+			Knockout: &KnockoutRule{AcceptedOptions: []string{"EU", "UK"}}}, "uk", false},
+		{"single choice unanswered", ScreeningQuestion{Type: QuestionSingleChoice,
+			Knockout: &KnockoutRule{AcceptedOptions: []string{"EU", "UK"}}}, nil, false},
+		{"multi choice with every required option", ScreeningQuestion{Type: QuestionMultiChoice,
+			Knockout: &KnockoutRule{RequiredOptions: []string{"Go", "SQL"}}}, []string{"SQL", "Docker", "Go"}, true},
+		{"multi choice missing a required option", ScreeningQuestion{Type: QuestionMultiChoice,
+			Knockout: &KnockoutRule{RequiredOptions: []string{"Go", "SQL"}}}, []string{"Go", "Docker"}, false},
+		{"multi choice unanswered", ScreeningQuestion{Type: QuestionMultiChoice,
+			Knockout: &KnockoutRule{RequiredOptions: []string{"Go"}}}, nil, false},
+		{"number within range", ScreeningQuestion{Type: QuestionNumber, Knockout: &KnockoutRule{Min: &low, Max: &high}}, 5.0, true},
+		{"number at minimum", ScreeningQuestion{Type: QuestionNumber, Knockout: &KnockoutRule{Min: &low, Max: &high}}, 2.0, true},
+		{"number at maximum", ScreeningQuestion{Type: QuestionNumber, Knockout: &KnockoutRule{Min: &low, Max: &high}}, 10.0, true},
+		{"number below minimum", ScreeningQuestion{Type: QuestionNumber, Knockout: &KnockoutRule{Min: &low, Max: &high}}, 1.5, false},
+		{"number above maximum", ScreeningQuestion{Type: QuestionNumber, Knockout: &KnockoutRule{Min: &low, Max: &high}}, 10.5, false},
+		{"number with minimum only", ScreeningQuestion{Type: QuestionNumber, Knockout: &KnockoutRule{Min: &low}}, 40.0, true},
+		{"number unanswered", ScreeningQuestion{Type: QuestionNumber, Knockout: &KnockoutRule{Min: &low}}, nil, false},
+		{"short text has no rules", ScreeningQuestion{Type: QuestionShortText, Knockout: &KnockoutRule{}}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.PassesKnockout(tt.answer); got != tt.want {
+				t.Errorf("PassesKnockout(%v) = %v, want %v", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
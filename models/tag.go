@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Names are unique within an organization, ignoring case.
+type Tag struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string    `json:"name" gorm:"type:varchar(50);not null"`
+	CreatedBy      uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func MigrateTagNames(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		duplicates := `SELECT id, FIRST_VALUE(id) OVER (
+			PARTITION BY organization_id, LOWER(name) ORDER BY created_at, id) AS keep FROM tags`
+		if err := tx.Exec(`INSERT INTO application_tags (application_id, tag_id)
+			SELECT application_tags.application_id, duplicates.keep FROM application_tags
+			JOIN (` + duplicates + `) AS duplicates ON duplicates.id = application_tags.tag_id
+			WHERE duplicates.id <> duplicates.keep
+			ON CONFLICT DO NOTHING`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM application_tags WHERE tag_id IN (
+			SELECT id FROM (` + duplicates + `) AS duplicates WHERE id <> keep)`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM tags WHERE id IN (
+			SELECT id FROM (` + duplicates + `) AS duplicates WHERE id <> keep)`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DROP INDEX IF EXISTS idx_tags_organization_name").Error; err != nil {
+			return err
+		}
+		return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_organization_lower_name ON tags (organization_id, LOWER(name))").Error
+	})
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
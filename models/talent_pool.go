@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TalentPoolStatus string
+
+const (
+	// TalentPoolPending candidates have been asked to join and not answered
+	TalentPoolPending  TalentPoolStatus = "pending"
+	TalentPoolActive   TalentPoolStatus = "active"
+	TalentPoolDeclined TalentPoolStatus = "declined"
+)
+
+// Candidates must consent before they can be invited to jobs.
+type TalentPoolCandidate struct {
+	ID                  uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID      uuid.UUID        `json:"organization_id" gorm:"type:uuid;not null;uniqueIndex:idx_talent_pool_organization_applicant"`
+	ApplicantID         uuid.UUID        `json:"applicant_id" gorm:"type:uuid;not null;uniqueIndex:idx_talent_pool_organization_applicant"`
+	Status              TalentPoolStatus `json:"status" gorm:"type:varchar(20);not null"`
+	SourceApplicationID *uuid.UUID       `json:"source_application_id" gorm:"type:uuid"`
+	Note                string           `json:"note,omitempty"`
+	AddedBy             uuid.UUID        `json:"added_by" gorm:"type:uuid;not null"`
+	ConsentedAt         *time.Time       `json:"consented_at"`
+	DeclinedAt          *time.Time       `json:"declined_at"`
+	CreatedAt           time.Time        `json:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at"`
+
+	// Relationships
+	Applicant    User                   `json:"applicant" gorm:"foreignKey:ApplicantID"`
+	Organization User                   `json:"organization" gorm:"foreignKey:OrganizationID"`
+	Invitations  []TalentPoolInvitation `json:"invitations,omitempty" gorm:"foreignKey:CandidateID"`
+}
+
+func (t *TalentPoolCandidate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+type TalentPoolInvitation struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CandidateID   uuid.UUID  `json:"candidate_id" gorm:"type:uuid;not null;index"`
+	ApplicantID   uuid.UUID  `json:"applicant_id" gorm:"type:uuid;not null;uniqueIndex:idx_talent_pool_invitations_job_applicant"`
+	JobID         uuid.UUID  `json:"job_id" gorm:"type:uuid;not null;uniqueIndex:idx_talent_pool_invitations_job_applicant"`
+	Message       string     `json:"message,omitempty" gorm:"type:text"`
+	InvitedBy     uuid.UUID  `json:"invited_by" gorm:"type:uuid;not null"`
+	ApplicationID *uuid.UUID `json:"application_id" gorm:"type:uuid"`
+	CreatedAt     time.Time  `json:"created_at"`
+
+	// Relationships
+	Job Job `json:"job" gorm:"foreignKey:JobID"`
+}
+
+func (i *TalentPoolInvitation) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
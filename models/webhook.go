@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookEventTypes lists the events an endpoint can subscribe to.
+var WebhookEventTypes = []string{"application.created", "application.status_changed"}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookEndpoint struct {
+	ID                  uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID      uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;index"`
+	URL                 string     `json:"url" gorm:"not null"`
+	Description         string     `json:"description"`
+	EventTypes          []string   `json:"event_types" gorm:"type:jsonb;serializer:json;not null"`
+	Secret              string     `json:"-" gorm:"not null"`
+	Enabled             bool       `json:"enabled" gorm:"not null;default:true"`
+	ConsecutiveFailures int        `json:"consecutive_failures" gorm:"not null;default:0"`
+	DisabledAt          *time.Time `json:"disabled_at"`
+	DisabledReason      string     `json:"disabled_reason,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at"`
+	CreatedBy           uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// Redeliveries share the original's EventID, so receivers can deduplicate.
+type WebhookDelivery struct {
+	ID             uuid.UUID                `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EndpointID     uuid.UUID                `json:"endpoint_id" gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID                `json:"event_id" gorm:"type:uuid;not null;index"`
+	EventType      string                   `json:"event_type" gorm:"type:varchar(50);not null"`
+	Payload        json.RawMessage          `json:"payload" gorm:"type:jsonb;not null"`
+	Status         WebhookDeliveryStatus    `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts       int                      `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time               `json:"next_attempt_at" gorm:"index"`
+	ResponseStatus int                      `json:"response_status"`
+	LastError      string                   `json:"last_error,omitempty"`
+	RedeliveryOf   *uuid.UUID               `json:"redelivery_of" gorm:"type:uuid"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	AttemptLog     []WebhookDeliveryAttempt `json:"attempt_log,omitempty" gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookDeliveryAttempt logs a single HTTP request made for a delivery.
+type WebhookDeliveryAttempt struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	DeliveryID     uuid.UUID `json:"delivery_id" gorm:"type:uuid;not null;index"`
+	Attempt        int       `json:"attempt" gorm:"not null"`
+	ResponseStatus int       `json:"response_status"`
+	ResponseBody   string    `json:"response_body" gorm:"type:text"`
+	Error          string    `json:"error,omitempty"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func IsWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribed reports whether the endpoint wants events of the given type.
+func (e WebhookEndpoint) Subscribed(eventType string) bool {
+	for _, t := range e.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *WebhookEndpoint) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+func (a *WebhookDeliveryAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"job-api/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultMaxAttempts applies to kinds registered without a limit.
+	DefaultMaxAttempts = 8
+
+	retryBase = 30 * time.Second
+	retryMax  = 6 * time.Hour
+
+	pollInterval        = time.Second
+	maintenanceInterval = time.Minute
+
+	// A task whose lease is not renewed for this long was abandoned by a
+	// crashed worker
+	Lease             = 5 * time.Minute
+	heartbeatInterval = Lease / 5
+
+	// Succeeded tasks are kept this long; dead ones until removed by hand
+	Retention = 7 * 24 * time.Hour
+)
+
+// Tasks may run more than once, so handlers must be safe to repeat.
+type Handler func(ctx context.Context, task models.QueueTask) error
+
+type kind struct {
+	handler     Handler
+	maxAttempts int
+}
+
+var (
+	mu    sync.RWMutex
+	kinds = make(map[string]kind)
+)
+
+// A maxAttempts below 1 uses DefaultMaxAttempts.
+func Register(name string, maxAttempts int, handler Handler) {
+	if maxAttempts < 1 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	kinds[name] = kind{handler: handler, maxAttempts: maxAttempts}
+}
+
+func lookup(name string) (kind, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	k, ok := kinds[name]
+	return k, ok
+}
+
+// Called inside a transaction, the task only exists if it commits.
+func Enqueue(tx *gorm.DB, name string, payload interface{}) error {
+	return EnqueueAt(tx, name, payload, time.Now())
+}
+
+// EnqueueAt adds a task that runs no earlier than runAt.
+func EnqueueAt(tx *gorm.DB, name string, payload interface{}, runAt time.Time) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	maxAttempts := DefaultMaxAttempts
+	if k, ok := lookup(name); ok {
+		maxAttempts = k.maxAttempts
+	}
+	return tx.Create(&models.QueueTask{
+		Kind:        name,
+		Payload:     data,
+		Status:      models.QueueTaskPending,
+		RunAt:       runAt,
+		MaxAttempts: maxAttempts,
+	}).Error
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent errors dead-letter the task straight away.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// RetryDelay doubles with each attempt, up to six hours.
+func RetryDelay(attempt int) time.Duration {
+	delay := retryBase << (attempt - 1)
+	if delay <= 0 || delay > retryMax {
+		return retryMax
+	}
+	return delay
+}
+
+func Start(ctx context.Context, db *gorm.DB, workers int) {
+	for i := 0; i < workers; i++ {
+		go work(ctx, db)
+	}
+
+	go func() {
+		for ctx.Err() == nil {
+			if err := maintain(db, time.Now()); err != nil {
+				log.Println("Failed to maintain task queue:", err)
+			}
+			sleep(ctx, maintenanceInterval)
+		}
+	}()
+}
+
+func work(ctx context.Context, db *gorm.DB) {
+	for ctx.Err() == nil {
+		task, ok, err := claim(db, time.Now())
+		if err != nil {
+			log.Println("Failed to claim task:", err)
+		}
+		if !ok {
+			sleep(ctx, pollInterval)
+			continue
+		}
+		runErr := runLeased(ctx, db, task)
+		if err := finish(db, task, runErr, time.Now()); err != nil {
+			log.Printf("Failed to record result of %s task %s: %v", task.Kind, task.ID, err)
+		}
+	}
+}
+
+// runLeased runs a task while renewing its lease. If the lease is lost, the
+// task was handed to another worker, so the run is cancelled.
+func runLeased(ctx context.Context, db *gorm.DB, task models.QueueTask) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				held, err := renew(db, task, time.Now())
+				if err != nil {
+					log.Printf("Failed to renew lease of %s task %s: %v", task.Kind, task.ID, err)
+				} else if !held {
+					log.Printf("Lost lease of %s task %s, stopping it", task.Kind, task.ID)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return run(runCtx, task)
+}
+
+func renew(db *gorm.DB, task models.QueueTask, now time.Time) (bool, error) {
+	result := db.Model(&models.QueueTask{}).
+		Where("id = ? AND status = ? AND attempts = ?", task.ID, models.QueueTaskRunning, task.Attempts).
+		Update("locked_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// claim takes the next due task. SKIP LOCKED lets workers claim tasks
+// concurrently without waiting on each other or taking the same task.
+func claim(db *gorm.DB, now time.Time) (models.QueueTask, bool, error) {
+	var tasks []models.QueueTask
+	err := db.Raw(`UPDATE queue_tasks SET status = ?, locked_at = ?, attempts = attempts + 1, updated_at = ?
+		WHERE id = (
+			SELECT id FROM queue_tasks WHERE status = ? AND run_at <= ?
+			ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED
+		) RETURNING *`,
+		models.QueueTaskRunning, now, now, models.QueueTaskPending, now).Scan(&tasks).Error
+	if err != nil || len(tasks) == 0 {
+		return models.QueueTask{}, false, err
+	}
+	return tasks[0], true, nil
+}
+
+func run(ctx context.Context, task models.QueueTask) (err error) {
+	k, ok := lookup(task.Kind)
+	if !ok {
+		return Permanent(fmt.Errorf("no handler registered for %s tasks", task.Kind))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return k.handler(ctx, task)
+}
+
+func finish(db *gorm.DB, task models.QueueTask, runErr error, now time.Time) error {
+	updates := map[string]interface{}{"locked_at": nil, "last_error": ""}
+
+	var permanent permanentError
+	switch {
+	case runErr == nil:
+		updates["status"] = models.QueueTaskSucceeded
+		updates["completed_at"] = now
+	case errors.As(runErr, &permanent) || task.Attempts >= task.MaxAttempts:
+		log.Printf("Dead-lettered %s task %s after %d attempts: %v", task.Kind, task.ID, task.Attempts, runErr)
+		updates["status"] = models.QueueTaskDead
+		updates["completed_at"] = now
+		updates["last_error"] = runErr.Error()
+	default:
+		updates["status"] = models.QueueTaskPending
+		updates["run_at"] = now.Add(RetryDelay(task.Attempts))
+		updates["last_error"] = runErr.Error()
+	}
+
+	// A task whose lease ran out may have been claimed again; leave it be
+	return db.Model(&models.QueueTask{}).
+		Where("id = ? AND status = ? AND attempts = ?", task.ID, models.QueueTaskRunning, task.Attempts).
+		Updates(updates).Error
+}
+
+func maintain(db *gorm.DB, now time.Time) error {
+	abandoned := func() *gorm.DB {
+		return db.Model(&models.QueueTask{}).Where("status = ? AND locked_at < ?", models.QueueTaskRunning, now.Add(-Lease))
+	}
+	const reason = "worker stopped renewing its lease"
+	if err := abandoned().Where("attempts >= max_attempts").
+		Updates(map[string]interface{}{"status": models.QueueTaskDead, "locked_at": nil, "completed_at": now, "last_error": reason}).
+		Error; err != nil {
+		return err
+	}
+	if err := abandoned().
+		Updates(map[string]interface{}{"status": models.QueueTaskPending, "locked_at": nil, "run_at": now, "last_error": reason}).
+		Error; err != nil {
+		return err
+	}
+	return db.Where("status = ? AND completed_at < ?", models.QueueTaskSucceeded, now.Add(-Retention)).
+		Delete(&models.QueueTask{}).Error
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// Depth counts the tasks of one kind in one status.
+type Depth struct {
+	Kind        string                 `json:"kind"`
+	Status      models.QueueTaskStatus `json:"status"`
+	Count       int64                  `json:"count"`
+	OldestRunAt *time.Time             `json:"oldest_run_at"`
+}
+
+func Stats(db *gorm.DB) ([]Depth, error) {
+	var depths []Depth
+	err := db.Model(&models.QueueTask{}).
+		Select("kind, status, COUNT(*) AS count, MIN(run_at) AS oldest_run_at").
+		Where("status <> ?", models.QueueTaskSucceeded).
+		Group("kind, status").Order("kind, status").
+		Scan(&depths).Error
+	return depths, err
+}
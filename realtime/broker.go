@@ -0,0 +1,74 @@
+package realtime
+
+import (
+	"sync"
+
+	"job-api/models"
+
+	"github.com/google/uuid"
+)
+
+// A client this far behind is disconnected and can resume from its last ID.
+const subscriptionBuffer = 64
+
+// C is closed when the subscription ends or the client falls too far behind.
+type Subscription struct {
+	UserID uuid.UUID
+	C      chan models.RealtimeEvent
+	closed bool
+}
+
+// Broker fans events out to the subscriptions of this server instance.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[uuid.UUID]map[*Subscription]bool
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[uuid.UUID]map[*Subscription]bool)}
+}
+
+func (b *Broker) Subscribe(userID uuid.UUID) *Subscription {
+	sub := &Subscription{UserID: userID, C: make(chan models.RealtimeEvent, subscriptionBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs[userID] == nil {
+		b.subs[userID] = make(map[*Subscription]bool)
+	}
+	b.subs[userID][sub] = true
+	return sub
+}
+
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(sub)
+}
+
+// Deliver passes an event to the local subscriptions of its users.
+func (b *Broker) Deliver(event models.RealtimeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, userID := range event.UserIDs {
+		for sub := range b.subs[userID] {
+			select {
+			case sub.C <- event:
+			default:
+				b.remove(sub)
+			}
+		}
+	}
+}
+
+func (b *Broker) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.C)
+	delete(b.subs[sub.UserID], sub)
+	if len(b.subs[sub.UserID]) == 0 {
+		delete(b.subs, sub.UserID)
+	}
+}
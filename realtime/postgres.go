@@ -0,0 +1,206 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"job-api/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// channel is the Postgres NOTIFY channel carrying new event IDs.
+const channel = "realtime_events"
+
+// Retention is how long events stay available for resuming.
+const Retention = 24 * time.Hour
+
+// ReplayOverlap is how far back a listener catches up after reconnecting.
+// Event IDs are taken when an event is stored but become visible when its
+// transaction commits, so a lower ID can appear after a higher one. The
+// overlap must be longer than any transaction that publishes events.
+const ReplayOverlap = 5 * time.Minute
+
+// seqLock is the advisory lock held while events are given a seq.
+const seqLock = 0x7365716c
+
+// Called inside a transaction, the event is only delivered if it commits.
+func Publish(tx *gorm.DB, eventType string, userIDs []uuid.UUID, data interface{}) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	event := models.RealtimeEvent{Type: eventType, UserIDs: uniqueIDs(userIDs), Data: payload}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+	return tx.Exec("SELECT pg_notify(?, ?)", channel, strconv.FormatInt(event.ID, 10)).Error
+}
+
+// Since returns a user's events with a seq after lastSeq, in seq order.
+func Since(db *gorm.DB, userID uuid.UUID, lastSeq int64, limit int) ([]models.RealtimeEvent, error) {
+	var events []models.RealtimeEvent
+	err := db.Where("user_ids @> ?", `["`+userID.String()+`"]`).
+		Where("seq > ?", lastSeq).
+		Order("seq ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// Seqs are taken and committed under a lock, so once a seq is visible every
+// lower one is too, and a stream resuming after a seq misses nothing.
+func assignSeq(db *gorm.DB, ids ...int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seqLock).Error; err != nil {
+			return err
+		}
+		query := `UPDATE realtime_events SET seq = next.seq FROM (
+			SELECT id, nextval('realtime_event_seq') AS seq FROM (
+				SELECT id FROM realtime_events WHERE seq IS NULL%s ORDER BY id
+			) pending
+		) next WHERE realtime_events.id = next.id`
+		if len(ids) == 0 {
+			return tx.Exec(fmt.Sprintf(query, "")).Error
+		}
+		return tx.Exec(fmt.Sprintf(query, " AND id IN ?"), ids).Error
+	})
+}
+
+// Listen reconnects after errors and then delivers the events it missed.
+// Transaction poolers do not relay notifications, so databaseURL must connect
+// directly.
+func Listen(ctx context.Context, databaseURL string, db *gorm.DB, broker *Broker) {
+	relay := &relay{broker: broker, seen: make(map[int64]time.Time), latest: time.Now()}
+	// Events from before this instance started were never meant for it
+	var recent []models.RealtimeEvent
+	db.Select("id", "created_at").Where("created_at >= ?", relay.latest.Add(-ReplayOverlap)).Find(&recent)
+	for _, event := range recent {
+		relay.seen[event.ID] = event.CreatedAt
+	}
+
+	backoff := time.Second
+	for ctx.Err() == nil {
+		err := listen(ctx, databaseURL, db, relay)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Realtime listener disconnected, retrying in %s: %v", backoff, err)
+		time.Sleep(backoff)
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func listen(ctx context.Context, databaseURL string, db *gorm.DB, relay *relay) error {
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+
+	// Catch up on anything published while we were not listening. Events
+	// committed since LISTEN are also notified; the relay skips repeats.
+	relay.prune()
+	if err := assignSeq(db); err != nil {
+		return err
+	}
+	var missed []models.RealtimeEvent
+	if err := db.Where("created_at >= ? AND seq IS NOT NULL", relay.latest.Add(-ReplayOverlap)).
+		Order("seq ASC").Find(&missed).Error; err != nil {
+		return err
+	}
+	for _, event := range missed {
+		relay.deliver(event)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		id, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil || relay.delivered(id) {
+			continue
+		}
+		if err := assignSeq(db, id); err != nil {
+			log.Printf("Failed to assign realtime event %d a seq: %v", id, err)
+			continue
+		}
+		var event models.RealtimeEvent
+		if err := db.First(&event, id).Error; err != nil {
+			log.Printf("Failed to load realtime event %d: %v", id, err)
+			continue
+		}
+		relay.deliver(event)
+	}
+}
+
+// relay remembers the events of the last ReplayOverlap, so catching up after
+// a reconnect does not repeat them.
+type relay struct {
+	broker  *Broker
+	seen    map[int64]time.Time
+	latest  time.Time
+	pruneAt int
+}
+
+func (r *relay) delivered(id int64) bool {
+	_, ok := r.seen[id]
+	return ok
+}
+
+func (r *relay) deliver(event models.RealtimeEvent) {
+	if r.delivered(event.ID) {
+		return
+	}
+	r.broker.Deliver(event)
+	r.seen[event.ID] = event.CreatedAt
+	if event.CreatedAt.After(r.latest) {
+		r.latest = event.CreatedAt
+	}
+	if len(r.seen) > r.pruneAt {
+		r.prune()
+	}
+}
+
+// prune forgets events too old to be caught up on again.
+func (r *relay) prune() {
+	cutoff := r.latest.Add(-ReplayOverlap)
+	for id, createdAt := range r.seen {
+		if createdAt.Before(cutoff) {
+			delete(r.seen, id)
+		}
+	}
+	r.pruneAt = 2*len(r.seen) + 1024
+}
+
+// Prune deletes events older than Retention.
+func Prune(db *gorm.DB) error {
+	return db.Where("created_at < ?", time.Now().Add(-Retention)).Delete(&models.RealtimeEvent{}).Error
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var unique []uuid.UUID
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
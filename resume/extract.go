@@ -0,0 +1,393 @@
+package resume
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const DOCXContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// maxExtractedSize bounds decompressed DOCX parts and PDF streams.
+const maxExtractedSize = 16 << 20
+
+// ExtractText returns the plain text of a PDF, DOCX or plain text resume.
+func ExtractText(data []byte, contentType string) (string, error) {
+	var text string
+	var err error
+
+	switch contentType {
+	case "application/pdf":
+		text, err = extractPDFText(data)
+	case DOCXContentType:
+		text, err = extractDOCXText(data)
+	case "text/plain":
+		text = string(data)
+	default:
+		return "", ErrUnsupportedType
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = cleanText(text)
+	if text == "" {
+		return "", errors.New("no text could be extracted from the resume")
+	}
+	return text, nil
+}
+
+// cleanText drops control characters and collapses runs of blank lines.
+func cleanText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return ' '
+		}
+		return r
+	}, text)
+
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if line == "" {
+			if !blank && len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func extractDOCXText(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range archive.File {
+		if entry.Name != "word/document.xml" {
+			continue
+		}
+		part, err := entry.Open()
+		if err != nil {
+			return "", err
+		}
+		defer part.Close()
+		return docxDocumentText(io.LimitReader(part, maxExtractedSize))
+	}
+	return "", errors.New("docx file has no word/document.xml part")
+}
+
+func docxDocumentText(r io.Reader) (string, error) {
+	decoder := xml.NewDecoder(r)
+	var out strings.Builder
+	inText := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				out.WriteByte('\t')
+			case "br", "cr":
+				out.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				out.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				out.Write(t)
+			}
+		}
+	}
+	return out.String(), nil
+}
+
+// Only uncompressed and Flate-compressed streams with simple font encodings
+// are read, which covers resumes exported from word processors.
+func extractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF")) {
+		return "", errors.New("not a PDF file")
+	}
+
+	var out strings.Builder
+	for _, content := range pdfContentStreams(data) {
+		writePDFContentText(&out, content)
+		out.WriteByte('\n')
+	}
+	return out.String(), nil
+}
+
+func pdfContentStreams(data []byte) [][]byte {
+	var streams [][]byte
+	// Many small streams can each inflate a lot, so the limit is shared
+	budget := int64(maxExtractedSize)
+	pos := 0
+	for budget > 0 {
+		i := bytes.Index(data[pos:], []byte("stream"))
+		if i < 0 {
+			break
+		}
+		start := pos + i
+		pos = start + len("stream")
+		if start >= 3 && string(data[start-3:start]) == "end" {
+			continue
+		}
+
+		bodyStart := pos
+		if bodyStart < len(data) && data[bodyStart] == '\r' {
+			bodyStart++
+		}
+		if bodyStart < len(data) && data[bodyStart] == '\n' {
+			bodyStart++
+		}
+		end := bytes.Index(data[bodyStart:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		body := data[bodyStart : bodyStart+end]
+		pos = bodyStart + end + len("endstream")
+
+		dictStart := bytes.LastIndex(data[:start], []byte("obj"))
+		if dictStart < 0 {
+			dictStart = 0
+		}
+		dict := data[dictStart:start]
+		if bytes.Contains(dict, []byte("/Image")) || bytes.Contains(dict, []byte("/FontFile")) ||
+			bytes.Contains(dict, []byte("/Length1")) || bytes.Contains(dict, []byte("/ObjStm")) ||
+			bytes.Contains(dict, []byte("/XRef")) {
+			continue
+		}
+
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			reader, err := zlib.NewReader(bytes.NewReader(body))
+			if err != nil {
+				continue
+			}
+			// Truncated streams are common and still hold useful text
+			body, _ = io.ReadAll(io.LimitReader(reader, budget))
+			reader.Close()
+			budget -= int64(len(body))
+		} else if bytes.Contains(dict, []byte("/Filter")) {
+			continue
+		}
+
+		if bytes.Contains(body, []byte("Tj")) || bytes.Contains(body, []byte("TJ")) {
+			streams = append(streams, body)
+		}
+	}
+	return streams
+}
+
+// writePDFContentText interprets the text operators of a content stream.
+func writePDFContentText(out *strings.Builder, content []byte) {
+	var numbers []float64
+	var array strings.Builder
+	var last string
+	inArray := false
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			var s string
+			s, i = readPDFLiteral(content, i)
+			if inArray {
+				array.WriteString(s)
+			} else {
+				last = s
+			}
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			i += 2
+		case c == '<':
+			var s string
+			s, i = readPDFHex(content, i)
+			if inArray {
+				array.WriteString(s)
+			} else {
+				last = s
+			}
+		case c == '[':
+			inArray = true
+			array.Reset()
+			i++
+		case c == ']':
+			inArray = false
+			last = array.String()
+			i++
+		case c == '/':
+			i++
+			for i < len(content) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+		case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+			start := i
+			i++
+			for i < len(content) && (content[i] == '.' || (content[i] >= '0' && content[i] <= '9')) {
+				i++
+			}
+			n, _ := strconv.ParseFloat(string(content[start:i]), 64)
+			// Large negative kerning inside TJ arrays separates words
+			if inArray && n < -200 {
+				array.WriteByte(' ')
+			}
+			numbers = append(numbers, n)
+		case isPDFDelimiter(c):
+			i++
+		default:
+			start := i
+			for i < len(content) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+			switch string(content[start:i]) {
+			case "Tj", "TJ":
+				out.WriteString(last)
+			case "'", "\"":
+				out.WriteByte('\n')
+				out.WriteString(last)
+			case "Td", "TD":
+				if len(numbers) >= 2 && numbers[len(numbers)-1] != 0 {
+					out.WriteByte('\n')
+				} else {
+					out.WriteByte(' ')
+				}
+			case "T*", "ET", "Tm":
+				out.WriteByte('\n')
+			}
+			numbers = numbers[:0]
+			last = ""
+		}
+	}
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0, '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func readPDFLiteral(content []byte, i int) (string, int) {
+	var out []byte
+	depth := 0
+	for i++; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			if depth == 0 {
+				return string(out), i + 1
+			}
+			depth--
+			out = append(out, c)
+		case '\\':
+			i++
+			if i >= len(content) {
+				break
+			}
+			switch e := content[i]; e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation
+				if e == '\r' && i+1 < len(content) && content[i+1] == '\n' {
+					i++
+				}
+			default:
+				if e >= '0' && e <= '7' {
+					value := 0
+					for n := 0; n < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; n++ {
+						value = value*8 + int(content[i]-'0')
+						i++
+					}
+					i--
+					out = append(out, byte(value))
+				} else {
+					out = append(out, e)
+				}
+			}
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(out), i
+}
+
+func readPDFHex(content []byte, i int) (string, int) {
+	end := bytes.IndexByte(content[i:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+	digits := bytes.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, content[i+1:i+end])
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	decoded := make([]byte, hex.DecodedLen(len(digits)))
+	n, err := hex.Decode(decoded, digits)
+	if err != nil {
+		return "", i + end + 1
+	}
+	decoded = decoded[:n]
+
+	// Two-byte strings with a zero high byte are UTF-16 style text
+	wide := len(decoded) >= 2 && len(decoded)%2 == 0
+	for j := 0; wide && j < len(decoded); j += 2 {
+		wide = decoded[j] == 0
+	}
+	if wide {
+		narrow := make([]byte, 0, len(decoded)/2)
+		for j := 1; j < len(decoded); j += 2 {
+			narrow = append(narrow, decoded[j])
+		}
+		decoded = narrow
+	}
+	return string(decoded), i + end + 1
+}
@@ -0,0 +1,397 @@
+package resume
+
+import (
+	"job-api/utils"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type section int
+
+const (
+	sectionNone section = iota
+	sectionExperience
+	sectionEducation
+	sectionSkills
+	sectionOther
+)
+
+var sectionHeadings = map[string]section{
+	"experience":              sectionExperience,
+	"work experience":         sectionExperience,
+	"professional experience": sectionExperience,
+	"employment":              sectionExperience,
+	"employment history":      sectionExperience,
+	"work history":            sectionExperience,
+	"education":               sectionEducation,
+	"academic background":     sectionEducation,
+	"education and training":  sectionEducation,
+	"skills":                  sectionSkills,
+	"technical skills":        sectionSkills,
+	"core skills":             sectionSkills,
+	"key skills":              sectionSkills,
+	"technologies":            sectionSkills,
+	"summary":                 sectionOther,
+	"profile":                 sectionOther,
+	"about me":                sectionOther,
+	"objective":               sectionOther,
+	"projects":                sectionOther,
+	"certifications":          sectionOther,
+	"languages":               sectionOther,
+	"interests":               sectionOther,
+	"awards":                  sectionOther,
+	"references":              sectionOther,
+	"contact":                 sectionOther,
+	"publications":            sectionOther,
+	"volunteering":            sectionOther,
+}
+
+// knownSkills are matched anywhere, not just under a skills heading.
+var knownSkills = []string{
+	"go", "golang", "python", "java", "javascript", "typescript", "c", "c++", "c#",
+	"ruby", "php", "rust", "kotlin", "swift", "scala", "elixir", "haskell", "r",
+	"sql", "postgresql", "mysql", "sqlite", "mongodb", "redis", "elasticsearch",
+	"kafka", "rabbitmq", "graphql", "grpc", "rest", "html", "css", "sass",
+	"react", "angular", "vue", "svelte", "next.js", "node.js", "express", "django",
+	"flask", "fastapi", "spring", "rails", "laravel", ".net", "gin", "gorm",
+	"docker", "kubernetes", "terraform", "ansible", "aws", "gcp", "azure", "linux",
+	"git", "ci/cd", "jenkins", "github actions", "microservices", "machine learning",
+	"deep learning", "tensorflow", "pytorch", "pandas", "numpy", "spark", "hadoop",
+	"airflow", "tableau", "power bi", "excel", "figma", "photoshop", "agile", "scrum",
+	"jira", "project management", "product management", "data analysis", "seo",
+	"salesforce", "accounting", "customer service", "sales", "marketing",
+}
+
+// Single letters and common words are only taken from a skills section
+var ambiguousSkills = map[string]bool{"c": true, "r": true, "go": true, "rest": true, "sales": true, "excel": true, "spring": true}
+
+var knownSkillPatterns = compileSkillPatterns(knownSkills)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().\-]{7,}\d`)
+	linkPattern  = regexp.MustCompile(`(?i)\b(?:https?://)?(?:www\.)?(?:linkedin\.com/in|github\.com|gitlab\.com)/[A-Za-z0-9_\-./]+`)
+
+	monthPattern     = `(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?`
+	dateRangePattern = regexp.MustCompile(`(?i)(?:(` + monthPattern + `)\s+|(\d{1,2})/)?((?:19|20)\d{2})\s*(?:-|–|—|to|until)\s*(?:(?:(` + monthPattern + `)\s+|(\d{1,2})/)?((?:19|20)\d{2})|(present|current|now|today))`)
+	yearsPattern     = regexp.MustCompile(`(?i)(\d{1,2}(?:\.\d)?)\+?\s*(?:years|yrs)\b(?:\s+of)?(?:\s+\w+)?\s+experience`)
+	yearPattern      = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+
+	degreePattern      = regexp.MustCompile(`(?i)\b(bachelor(?:'s)?(?: of [a-z ]+)?|master(?:'s)?(?: of [a-z ]+)?|ph\.?d\.?|doctorate|mba|b\.?sc?\.?|m\.?sc?\.?|b\.?a\.?|m\.?a\.?|b\.?eng\.?|m\.?eng\.?|associate(?:'s)? degree|diploma|high school)(?:\s+in\s+[A-Za-z &]+)?`)
+	institutionPattern = regexp.MustCompile(`(?i)[A-Z][A-Za-z.&' ]*(?:university|college|institute|school|academy)(?: of [A-Za-z ]+)?|(?:university|college|institute) of [A-Za-z ]+`)
+	roleSeparators     = []string{" at ", " @ ", " | ", " — ", " – ", " - ", ", "}
+)
+
+var monthNumbers = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+func compileSkillPatterns(skills []string) map[string]*regexp.Regexp {
+	patterns := make(map[string]*regexp.Regexp, len(skills))
+	for _, skill := range skills {
+		patterns[skill] = regexp.MustCompile(`(?i)(?:^|[^a-z0-9+#.])` + regexp.QuoteMeta(skill) + `(?:$|[^a-z0-9+#])`)
+	}
+	return patterns
+}
+
+func ParseText(text string) *Result {
+	result := &Result{Text: text}
+	sections := splitSections(text)
+
+	result.Contact = parseContact(text)
+	result.Skills = parseSkills(text, sections[sectionSkills])
+	result.Employers = parseEmployers(sections[sectionExperience])
+	result.Education = parseEducation(sections[sectionEducation])
+	result.YearsExperience = yearsOfExperience(text, result.Employers)
+	return result
+}
+
+func splitSections(text string) map[section][]string {
+	sections := make(map[section][]string)
+	current := sectionNone
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		heading := strings.ToLower(strings.Trim(line, " :•-_=*#"))
+		if s, ok := sectionHeadings[heading]; ok && len(line) < 40 {
+			current = s
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+	return sections
+}
+
+func parseContact(text string) Contact {
+	var contact Contact
+	contact.Email = emailPattern.FindString(text)
+
+	for _, match := range phonePattern.FindAllString(text, -1) {
+		digits := 0
+		for _, r := range match {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		// Skip date ranges such as 2019 - 2021
+		if digits >= 9 && digits <= 15 && !dateRangePattern.MatchString(match) {
+			contact.Phone = strings.TrimSpace(match)
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, link := range linkPattern.FindAllString(text, -1) {
+		link = strings.TrimRight(link, "./")
+		if !seen[strings.ToLower(link)] {
+			seen[strings.ToLower(link)] = true
+			contact.Links = append(contact.Links, link)
+		}
+	}
+
+	// The name is usually the first short line without contact details
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		words := strings.Fields(line)
+		if len(words) >= 2 && len(words) <= 4 && !strings.ContainsAny(line, "@0123456789/:|,") {
+			contact.Name = line
+		}
+		break
+	}
+	return contact
+}
+
+func parseSkills(text string, skillLines []string) []string {
+	var skills []string
+	for _, line := range skillLines {
+		// Drop labels such as "Languages: Go, Python"
+		if idx := strings.Index(line, ":"); idx >= 0 && idx < 30 {
+			line = line[idx+1:]
+		}
+		for _, skill := range strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ';' || r == '|' || r == '•' || r == '·' || r == '\t'
+		}) {
+			skill = strings.Trim(skill, " -*.")
+			if skill != "" && len(skill) <= 40 && len(strings.Fields(skill)) <= 4 {
+				skills = append(skills, skill)
+			}
+		}
+	}
+
+	for _, skill := range knownSkills {
+		if ambiguousSkills[skill] {
+			continue
+		}
+		if knownSkillPatterns[skill].MatchString(text) {
+			skills = append(skills, skill)
+		}
+	}
+	return utils.NormalizeSkills(skills)
+}
+
+func parseEmployers(lines []string) []Employer {
+	var employers []Employer
+	previous := ""
+	for _, line := range lines {
+		match := dateRangePattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			previous = line
+			continue
+		}
+
+		header := strings.TrimSpace(line[:match[0]] + " " + line[match[1]:])
+		header = strings.Trim(header, " |,-–—()")
+		if header == "" {
+			header = previous
+		}
+		previous = ""
+		if header == "" {
+			continue
+		}
+
+		title, company := splitRole(header)
+		start, end, current := dateRangeBounds(line, match)
+		employers = append(employers, Employer{
+			Company:   company,
+			Title:     title,
+			StartDate: start.Format("2006-01"),
+			EndDate:   formatEnd(end, current),
+			Current:   current,
+		})
+	}
+	return employers
+}
+
+// Without a recognised separator the whole header is the company.
+func splitRole(header string) (title, company string) {
+	for _, separator := range roleSeparators {
+		if idx := strings.Index(header, separator); idx > 0 {
+			left := strings.TrimSpace(header[:idx])
+			right := strings.TrimSpace(header[idx+len(separator):])
+			if separator == " at " || separator == " @ " || separator == ", " {
+				return left, right
+			}
+			// "Company | Title" is as common as "Title | Company"
+			if looksLikeTitle(right) && !looksLikeTitle(left) {
+				return right, left
+			}
+			return left, right
+		}
+	}
+	return "", header
+}
+
+var titleWords = []string{"engineer", "developer", "manager", "analyst", "designer", "intern", "lead", "director", "consultant", "architect", "specialist", "officer", "assistant", "scientist", "administrator", "coordinator", "head", "vp", "president", "accountant", "associate"}
+
+func looksLikeTitle(s string) bool {
+	lower := strings.ToLower(s)
+	for _, word := range titleWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func dateRangeBounds(line string, match []int) (start, end time.Time, current bool) {
+	group := func(n int) string {
+		if match[2*n] < 0 {
+			return ""
+		}
+		return line[match[2*n]:match[2*n+1]]
+	}
+
+	start = monthYear(group(1), group(2), group(3), 1)
+	if group(7) != "" {
+		return start, time.Now(), true
+	}
+	return start, monthYear(group(4), group(5), group(6), 12), false
+}
+
+func monthYear(monthName, monthNumber, year string, defaultMonth int) time.Time {
+	y, _ := strconv.Atoi(year)
+	month := defaultMonth
+	if monthName != "" {
+		key := strings.ToLower(monthName)
+		if len(key) > 3 {
+			key = key[:3]
+		}
+		month = monthNumbers[key]
+	} else if monthNumber != "" {
+		if m, err := strconv.Atoi(monthNumber); err == nil && m >= 1 && m <= 12 {
+			month = m
+		}
+	}
+	return time.Date(y, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func formatEnd(end time.Time, current bool) string {
+	if current {
+		return ""
+	}
+	return end.Format("2006-01")
+}
+
+func parseEducation(lines []string) []Education {
+	var education []Education
+	for i, line := range lines {
+		degree := degreePattern.FindString(line)
+		institution := institutionPattern.FindString(line)
+		if degree == "" && institution == "" {
+			continue
+		}
+
+		year := 0
+		if years := yearPattern.FindAllString(line, -1); len(years) > 0 {
+			year, _ = strconv.Atoi(years[len(years)-1])
+		}
+
+		// Degree and institution are often on consecutive lines
+		if len(education) > 0 && i > 0 {
+			last := &education[len(education)-1]
+			if degree == "" && last.Institution == "" {
+				last.Institution = strings.TrimSpace(institution)
+			} else if institution == "" && last.Degree == "" {
+				last.Degree = strings.TrimSpace(degree)
+			} else {
+				last = nil
+			}
+			if last != nil {
+				if last.Year == 0 {
+					last.Year = year
+				}
+				continue
+			}
+		}
+
+		education = append(education, Education{
+			Institution: strings.TrimSpace(institution),
+			Degree:      strings.TrimSpace(degree),
+			Year:        year,
+		})
+	}
+	return education
+}
+
+// Without an explicit "N years of experience", employment ranges are added
+// up, counting overlaps once.
+func yearsOfExperience(text string, employers []Employer) *float64 {
+	if match := yearsPattern.FindStringSubmatch(text); match != nil {
+		if years, err := strconv.ParseFloat(match[1], 64); err == nil {
+			return &years
+		}
+	}
+	if len(employers) == 0 {
+		return nil
+	}
+
+	type period struct{ start, end time.Time }
+	var periods []period
+	for _, employer := range employers {
+		start, err := time.Parse("2006-01", employer.StartDate)
+		if err != nil {
+			continue
+		}
+		end := time.Now()
+		if !employer.Current {
+			if end, err = time.Parse("2006-01", employer.EndDate); err != nil {
+				continue
+			}
+			end = end.AddDate(0, 1, 0)
+		}
+		if end.After(start) {
+			periods = append(periods, period{start, end})
+		}
+	}
+	if len(periods) == 0 {
+		return nil
+	}
+
+	sort.Slice(periods, func(i, j int) bool { return periods[i].start.Before(periods[j].start) })
+	var total time.Duration
+	current := periods[0]
+	for _, p := range periods[1:] {
+		if !p.start.After(current.end) {
+			if p.end.After(current.end) {
+				current.end = p.end
+			}
+			continue
+		}
+		total += current.end.Sub(current.start)
+		current = p
+	}
+	total += current.end.Sub(current.start)
+
+	years := math.Round(total.Hours()/24/365.25*10) / 10
+	return &years
+}
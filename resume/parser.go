@@ -0,0 +1,66 @@
+package resume
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUnsupportedType = errors.New("unsupported resume content type")
+
+// Parser implementations may run locally or call an external service.
+type Parser interface {
+	Name() string
+	Parse(ctx context.Context, data []byte, contentType string) (*Result, error)
+}
+
+type Contact struct {
+	Name  string   `json:"name,omitempty"`
+	Email string   `json:"email,omitempty"`
+	Phone string   `json:"phone,omitempty"`
+	Links []string `json:"links,omitempty"`
+}
+
+type Employer struct {
+	Company   string `json:"company"`
+	Title     string `json:"title,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+	Current   bool   `json:"current"`
+}
+
+type Education struct {
+	Institution string `json:"institution,omitempty"`
+	Degree      string `json:"degree,omitempty"`
+	Year        int    `json:"year,omitempty"`
+}
+
+type Result struct {
+	Text            string      `json:"text"`
+	Contact         Contact     `json:"contact"`
+	Skills          []string    `json:"skills"`
+	Employers       []Employer  `json:"employers"`
+	Education       []Education `json:"education"`
+	YearsExperience *float64    `json:"years_experience"`
+}
+
+// LocalParser reads PDF, DOCX and plain text in-process.
+type LocalParser struct{}
+
+func NewLocalParser() *LocalParser {
+	return &LocalParser{}
+}
+
+func (p *LocalParser) Name() string {
+	return "local"
+}
+
+func (p *LocalParser) Parse(ctx context.Context, data []byte, contentType string) (*Result, error) {
+	text, err := ExtractText(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ParseText(text), nil
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icalTimeFormat = "20060102T150405Z"
+
+type ICalMethod string
+
+const (
+	ICalRequest ICalMethod = "REQUEST"
+	ICalCancel  ICalMethod = "CANCEL"
+)
+
+type ICalAttendee struct {
+	Name  string
+	Email string
+}
+
+// Each change to an event must keep its UID and increase Sequence so
+// calendar clients replace the earlier copy.
+type ICalEvent struct {
+	UID         string
+	Sequence    int
+	Method      ICalMethod
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Organizer   ICalAttendee
+	Attendees   []ICalAttendee
+	Stamp       time.Time
+}
+
+// Encode renders the event as an RFC 5545 iCalendar object.
+func (e ICalEvent) Encode() []byte {
+	var buf bytes.Buffer
+	write := func(line string) {
+		writeFoldedLine(&buf, line)
+	}
+
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	status := "CONFIRMED"
+	if e.Method == ICalCancel {
+		status = "CANCELLED"
+	}
+
+	write("BEGIN:VCALENDAR")
+	write("PRODID:-//Job API//Interviews//EN")
+	write("VERSION:2.0")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:" + string(e.Method))
+	write("BEGIN:VEVENT")
+	write("UID:" + e.UID)
+	write(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+	write("DTSTAMP:" + stamp.UTC().Format(icalTimeFormat))
+	write("DTSTART:" + e.Start.UTC().Format(icalTimeFormat))
+	write("DTEND:" + e.End.UTC().Format(icalTimeFormat))
+	write("SUMMARY:" + escapeICalText(e.Summary))
+	if e.Description != "" {
+		write("DESCRIPTION:" + escapeICalText(e.Description))
+	}
+	if e.Location != "" {
+		write("LOCATION:" + escapeICalText(e.Location))
+	}
+	if e.URL != "" {
+		write("URL:" + e.URL)
+	}
+	write("STATUS:" + status)
+	if e.Organizer.Email != "" {
+		write(fmt.Sprintf("ORGANIZER;CN=%s:mailto:%s", quoteICalParam(e.Organizer.Name), e.Organizer.Email))
+	}
+	for _, attendee := range e.Attendees {
+		write(fmt.Sprintf("ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:%s",
+			quoteICalParam(attendee.Name), attendee.Email))
+	}
+	write("END:VEVENT")
+	write("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+func escapeICalText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return replacer.Replace(text)
+}
+
+// quoteICalParam quotes parameter values, which may not contain quotes.
+func quoteICalParam(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, "'") + `"`
+}
+
+// Lines fold at 75 octets without splitting UTF-8 sequences (RFC 5545 3.1).
+func writeFoldedLine(buf *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = 74
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces identifying text removed by RedactText.
+const Redacted = "[redacted]"
+
+var (
+	redactEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	redactURLPattern   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b(?:linkedin|github)\.com/\S+`)
+	redactPhonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().\-]{6,}\d`)
+)
+
+// RedactText also removes email addresses, links and phone numbers. Terms
+// match whole words, ignoring case.
+func RedactText(text string, terms []string) string {
+	text = redactEmailPattern.ReplaceAllString(text, Redacted)
+	text = redactURLPattern.ReplaceAllString(text, Redacted)
+	text = redactPhonePattern.ReplaceAllString(text, Redacted)
+
+	// Longer terms first, so a full name is replaced before its parts
+	sorted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); len(term) > 1 {
+			sorted = append(sorted, term)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, term := range sorted {
+		pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(term) + `\b`)
+		text = pattern.ReplaceAllString(text, Redacted)
+	}
+	return text
+}
+
+// NameTerms returns a name and each of its parts, for RedactText.
+func NameTerms(names ...string) []string {
+	var terms []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		terms = append(terms, name)
+		terms = append(terms, strings.Fields(name)...)
+	}
+	return terms
+}
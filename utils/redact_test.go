@@ -0,0 +1,75 @@
+package utils
+
+import "testing"
+
+func TestRedactText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{
+			name: "email address",
+			text: "Reach me at jane.doe@example.com today",
+			want: "Reach me at [redacted] today",
+		},
+		{
+			name: "link with scheme",
+			text: "See https://github.com/janedoe/projects",
+			want: "See [redacted]",
+		},
+		{
+			name: "link without scheme",
+			text: "linkedin.com/in/jdoe for more",
+			want: "[redacted] for more",
+		},
+		{
+			name: "www link",
+			text: "Portfolio: www.janedoe.dev",
+			want: "Portfolio: [redacted]",
+		},
+		{
+			name: "phone number",
+			text: "Call +1 (555) 123-4567 now",
+			want: "Call [redacted] now",
+		},
+		{
+			name:  "full name and its parts ignoring case",
+			text:  "Jane Doe wrote this; jane led it and DOE reviewed",
+			terms: NameTerms("Jane Doe"),
+			want:  "[redacted] wrote this; [redacted] led it and [redacted] reviewed",
+		},
+		{
+			name:  "whole words only",
+			text:  "Janet met Doenitz",
+			terms: NameTerms("Jane Doe"),
+			want:  "Janet met Doenitz",
+		},
+		{
+			name:  "hyphenated name",
+			text:  "Anne-Marie Smith applied, Anne-Marie is keen",
+			terms: NameTerms("Anne-Marie Smith"),
+			want:  "[redacted] applied, [redacted] is keen",
+		},
+		{
+			name:  "single letters and blank terms are ignored",
+			text:  "J. worked on a team",
+			terms: []string{"J", "a", "", "   "},
+			want:  "J. worked on a team",
+		},
+		{
+			name:  "nothing identifying",
+			text:  "5 years of Go and PostgreSQL",
+			terms: NameTerms("Jane Doe"),
+			want:  "5 years of Go and PostgreSQL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactText(tt.text, tt.terms); got != tt.want {
+				t.Errorf("RedactText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var tokenRegex = regexp.MustCompile(`[a-z0-9][a-z0-9+#.]*`)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "has": true, "have": true, "in": true, "is": true,
+	"it": true, "its": true, "of": true, "on": true, "or": true, "our": true, "that": true,
+	"the": true, "their": true, "this": true, "to": true, "we": true, "will": true,
+	"with": true, "you": true, "your": true, "who": true, "looking": true, "join": true,
+	"team": true, "work": true, "role": true, "job": true, "candidate": true,
+}
+
+// Tokenize lowercases text and splits it into terms suitable for TF-IDF scoring.
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, token := range tokenRegex.FindAllString(strings.ToLower(text), -1) {
+		token = strings.TrimRight(token, ".")
+		if len(token) < 2 || stopWords[token] {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// NormalizeSkills lowercases, trims and de-duplicates a skill list.
+func NormalizeSkills(skills []string) []string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		normalized = append(normalized, skill)
+	}
+	return normalized
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+func unsubscribeSecret() []byte {
+	return []byte(os.Getenv("UNSUBSCRIBE_SECRET"))
+}
+
+// An empty type unsubscribes from all email. Links do not expire so old
+// emails keep working.
+func SignUnsubscribe(userID uuid.UUID, notificationType string) string {
+	mac := hmac.New(sha256.New, unsubscribeSecret())
+	mac.Write([]byte("unsubscribe:" + userID.String() + ":" + notificationType))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Nothing verifies while UNSUBSCRIBE_SECRET is unset.
+func VerifyUnsubscribe(userID uuid.UUID, notificationType, signature string) bool {
+	if len(unsubscribeSecret()) == 0 {
+		return false
+	}
+	return hmac.Equal([]byte(SignUnsubscribe(userID, notificationType)), []byte(signature))
+}
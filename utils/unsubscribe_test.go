@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyUnsubscribe(t *testing.T) {
+	t.Setenv("UNSUBSCRIBE_SECRET", "unsubscribe-secret")
+	userID := uuid.MustParse("7b0e7a4e-3f1c-4c1e-9a55-0a4f8a7c2b11")
+	otherUserID := uuid.MustParse("0f2d9c58-8e3a-4b7e-a1c4-5d6e7f809a1b")
+	signature := SignUnsubscribe(userID, "interview_scheduled")
+
+	tests := []struct {
+		name             string
+		userID           uuid.UUID
+		notificationType string
+		signature        string
+		want             bool
+	}{
+		{"valid", userID, "interview_scheduled", signature, true},
+		{"other user", otherUserID, "interview_scheduled", signature, false},
+		{"other type", userID, "offer_received", signature, false},
+		{"all email", userID, "", signature, false},
+		{"empty signature", userID, "interview_scheduled", "", false},
+		{"uppercase signature", userID, "interview_scheduled", strings.ToUpper(signature), false},
+		{"truncated signature", userID, "interview_scheduled", signature[:len(signature)-1], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyUnsubscribe(tt.userID, tt.notificationType, tt.signature); got != tt.want {
+				t.Errorf("VerifyUnsubscribe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("all email link", func(t *testing.T) {
+		if !VerifyUnsubscribe(userID, "", SignUnsubscribe(userID, "")) {
+			t.Error("VerifyUnsubscribe() = false for an all-email link, want true")
+		}
+	})
+
+	t.Run("rotated secret", func(t *testing.T) {
+		t.Setenv("UNSUBSCRIBE_SECRET", "rotated-secret")
+		if VerifyUnsubscribe(userID, "interview_scheduled", signature) {
+			t.Error("VerifyUnsubscribe() = true after the secret changed, want false")
+		}
+	})
+
+	t.Run("secret unset", func(t *testing.T) {
+		t.Setenv("UNSUBSCRIBE_SECRET", "")
+		if VerifyUnsubscribe(userID, "interview_scheduled", SignUnsubscribe(userID, "interview_scheduled")) {
+			t.Error("VerifyUnsubscribe() = true without a secret, want false")
+		}
+	})
+}
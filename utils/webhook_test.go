@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"testing"
+)
+
+// verifyWebhook checks a signature the way the README tells receivers to.
+func verifyWebhook(secret, timestamp string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	return hmac.Equal([]byte("sha256="+hex.EncodeToString(mac.Sum(nil))), []byte(signature))
+}
+
+func TestSignWebhook(t *testing.T) {
+	const secret = "whsec_test"
+	const timestamp = int64(1700000000)
+	payload := []byte(`{"event":"application.created"}`)
+
+	want := "sha256=d53f36e6e6c342c28ec5ecaae47503a0bfd96791247d3e44461b1f5beba23102"
+	if got := SignWebhook(secret, timestamp, payload); got != want {
+		t.Fatalf("SignWebhook() = %s, want %s", got, want)
+	}
+
+	signature := SignWebhook(secret, timestamp, payload)
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		want      bool
+	}{
+		{"unchanged", secret, timestamp, payload, true},
+		{"other secret", "whsec_other", timestamp, payload, false},
+		{"replayed with a new timestamp", secret, timestamp + 300, payload, false},
+		{"tampered body", secret, timestamp, []byte(`{"event":"application.deleted"}`), false},
+		{"body with trailing newline", secret, timestamp, append(append([]byte(nil), payload...), '\n'), false},
+		{"empty body", secret, timestamp, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyWebhook(tt.secret, strconv.FormatInt(tt.timestamp, 10), tt.body, signature); got != tt.want {
+				t.Errorf("verifyWebhook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"93.184.216.34", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"192.0.0.8", false},
+		{"198.18.0.1", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"224.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := PublicIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("PublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+
+	if PublicIP(nil) {
+		t.Error("PublicIP(nil) = true, want false")
+	}
+}